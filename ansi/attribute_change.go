@@ -8,15 +8,15 @@ import (
 )
 
 type AttributeChange interface {
-	// Apply to a string
+	// Apply to a string (colours downsampled to the colour profile)
 	ApplyTo(str string) string
-	// Get the ansi code for this set
+	// Get the ansi code for this set (colours downsampled to the colour profile)
 	GetCodeString() string
 	// Get the underlying codes
 	GetCodes() []int
 }
 
-var ResetAll = attributes([]int{0})
+var ResetAll = attributes([]int{resetAllCode})
 
 // ----------------------------------------------------------------------------------------------------------------------------
 // Implementation
@@ -31,7 +31,7 @@ func (a attributes) ApplyTo(str string) string {
 }
 
 func (a attributes) GetCodeString() string {
-	return createAnsiCode(a)
+	return createAnsiCode(downsampleCodes(a, colorProfile))
 }
 
 func (a attributes) GetCodes() []int {
@@ -41,8 +41,7 @@ func (a attributes) GetCodes() []int {
 // ----------------------------------------------------------------------------------------------------------------------------
 // internal
 // ----------------------------------------------------------------------------------------------------------------------------
-var escape = '\x9b'
-var escapeStr = string(escape)
+var resetAllCode = 0
 
 func newAttributeChange(codes []int) AttributeChange {
 	return attributes(codes)
@@ -51,7 +50,7 @@ func newAttributeChange(codes []int) AttributeChange {
 func createAnsiCode(codes []int) string {
 	text := strings.Builder{}
 	if len(codes) > 0 {
-		sep := escapeStr
+		sep := getCsi()
 		for _, code := range codes {
 			text.WriteString(fmt.Sprintf("%s%d", sep, code))
 			sep = ";"
@@ -63,31 +62,40 @@ func createAnsiCode(codes []int) string {
 
 func getDeltaCodes(oldAttribs, newAttribs Attributes) []int {
 	codes := make([]int, 0, 2)
-	if code, required := colorModificationCode(oldAttribs.Foreground, newAttribs.Foreground); required {
-		codes = append(codes, code)
+	if colCodes, required := colorModificationCodes(oldAttribs.Foreground, newAttribs.Foreground); required {
+		codes = append(codes, colCodes...)
 	}
 	// Handle background as foreground
-	if code, required := colorModificationCode(oldAttribs.Background, newAttribs.Background); required {
-		codes = append(codes, int(convertForegroundToBackgroundColor(color.Color(code))))
+	if colCodes, required := colorModificationCodes(oldAttribs.Background, newAttribs.Background); required {
+		colCodes[0] = int(convertForegroundToBackgroundColor(color.Color(colCodes[0])))
+		codes = append(codes, colCodes...)
 	}
+	codes = append(codes, getStyleDeltaCodes(oldAttribs, newAttribs)...)
 	return codes
 }
 
-func colorModificationCode(oldColor, newColor color.Color) (code int, required bool) {
+func colorModificationCodes(oldColor, newColor color.Color) (codes []int, required bool) {
 	// No change
 	if oldColor == newColor {
-		return 0, false
+		return nil, false
 	}
 	// Reset color (already removed none->none above)
 	if newColor == color.None {
-		return resetColorCode, true
+		return []int{resetColorCode}, true
 	}
-	return int(newColor), true
+	return colorCodes(newColor), true
 }
 
 func modifyAttributes(attributes Attributes, delta AttributeChange) Attributes {
 	newAttribs := attributes
-	for _,code := range delta.GetCodes() {
+	codes := delta.GetCodes()
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		// Reset all
+		if code == resetAllCode {
+			newAttribs = NoAttributes
+			continue
+		}
 		// Reset foreground/background
 		if code == resetColorCode {
 			newAttribs.Foreground = color.None
@@ -97,6 +105,23 @@ func modifyAttributes(attributes Attributes, delta AttributeChange) Attributes {
 			newAttribs.Background = color.None
 			continue
 		}
+		// Extended foreground/background
+		if code == extendedColorCode || code == int(convertForegroundToBackgroundColor(color.Color(extendedColorCode))) {
+			col, length, ok := parseExtendedColor(codes[i+1:])
+			i += length
+			if ok {
+				if code == extendedColorCode {
+					newAttribs.Foreground = col
+				} else {
+					newAttribs.Background = col
+				}
+			}
+			continue
+		}
+		// Text styles
+		if modifyStyle(&newAttribs, code) {
+			continue
+		}
 		col := color.Color(code)
 		// Foreground
 		if isForegroundColor(col) {
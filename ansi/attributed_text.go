@@ -0,0 +1,111 @@
+package ansi
+
+// Text with attributes
+// Positions are in terminal cells, operations return new text
+type AttributedText struct {
+	clusters []attributedCluster
+}
+
+func NewAttributedText(parts ...AttributeString) AttributedText {
+	return AttributedText{splitAttributedClusters(parts)}
+}
+
+// Parse string containing escape sequences
+func ParseAttributedText(str string) AttributedText {
+	return NewAttributedText(ParseString(str)...)
+}
+
+// Segments with the same attributes
+func (t AttributedText) Parts() []AttributeString {
+	return joinAttributedClusters(t.clusters)
+}
+
+// Width in terminal cells
+func (t AttributedText) Width() int {
+	return clustersWidth(t.clusters)
+}
+
+// Get the character occupying the cell
+func (t AttributedText) CellAt(cell int) (char AttributeString, ok bool) {
+	pos := 0
+	for _, cluster := range t.clusters {
+		if pos <= cell && cell < pos+cluster.width {
+			return AttributeString{cluster.text, cluster.attributes, cluster.link}, true
+		}
+		pos += cluster.width
+	}
+	return AttributeString{}, false
+}
+
+// Text in cells [start,end)
+// Wide characters not entirely within the range are excluded
+func (t AttributedText) Slice(start, end int) AttributedText {
+	startIdx := t.index(start)
+	endIdx := t.indexBefore(end)
+	if endIdx < startIdx {
+		endIdx = startIdx
+	}
+	return AttributedText{t.clusters[startIdx:endIdx]}
+}
+
+func (t AttributedText) Append(other AttributedText) AttributedText {
+	return t.Insert(t.Width(), other)
+}
+
+// Insert other at the cell (before any wide character occupying it)
+func (t AttributedText) Insert(cell int, other AttributedText) AttributedText {
+	idx := t.indexBefore(cell)
+	return AttributedText{concatClusters(t.clusters[:idx], other.clusters, t.clusters[idx:])}
+}
+
+// Replace cells [start,end) with other
+// Wide characters partially within the range are also replaced
+func (t AttributedText) Replace(start, end int, other AttributedText) AttributedText {
+	startIdx := t.indexBefore(start)
+	endIdx := t.index(end)
+	if endIdx < startIdx {
+		endIdx = startIdx
+	}
+	return AttributedText{concatClusters(t.clusters[:startIdx], other.clusters, t.clusters[endIdx:])}
+}
+
+// Render with the minimal escape sequences between segments, ending with a reset
+func (t AttributedText) String() string {
+	return renderParts(t.Parts())
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Index of the first character starting at or after cell
+func (t AttributedText) index(cell int) int {
+	pos := 0
+	for i, cluster := range t.clusters {
+		if pos >= cell {
+			return i
+		}
+		pos += cluster.width
+	}
+	return len(t.clusters)
+}
+
+// Index of the first character ending after cell
+func (t AttributedText) indexBefore(cell int) int {
+	pos := 0
+	for i, cluster := range t.clusters {
+		if pos+cluster.width > cell {
+			return i
+		}
+		pos += cluster.width
+	}
+	return len(t.clusters)
+}
+
+func concatClusters(clusterSets ...[]attributedCluster) []attributedCluster {
+	clusters := make([]attributedCluster, 0)
+	for _, set := range clusterSets {
+		clusters = append(clusters, set...)
+	}
+	return clusters
+}
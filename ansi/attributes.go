@@ -2,19 +2,36 @@ package ansi
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/atrico-go/console/ansi/color"
 )
 
 type Attributes struct {
-	Foreground color.Color
-	Background color.Color
+	Foreground    color.Color
+	Background    color.Color
+	Bold          bool
+	Dim           bool
+	Italic        bool
+	Underline     bool
+	Blink         bool
+	Reverse       bool
+	Hidden        bool
+	Strikethrough bool
 }
 
-var NoAttributes = Attributes{color.None, color.None}
+var NoAttributes = Attributes{Foreground: color.None, Background: color.None}
 
 func (a Attributes) String() string {
-	return fmt.Sprintf("[%s,%s]", a.Foreground, a.Background)
+	text := strings.Builder{}
+	text.WriteString(fmt.Sprintf("[%s,%s", a.Foreground, a.Background))
+	for _, style := range styles {
+		if *style.field(&a) {
+			text.WriteString(fmt.Sprintf(",%s", style.name))
+		}
+	}
+	text.WriteString("]")
+	return text.String()
 }
 
 // Get a change to create this state from no attributes
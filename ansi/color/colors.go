@@ -29,6 +29,11 @@ const (
 	None		Color = -1
 )
 
+// The 16 standard colours
+func StandardColors() []Color {
+	return append([]Color{}, standardColors...)
+}
+
 func (c Color) String() string {
 	switch c {
 	case Black:
@@ -66,5 +71,8 @@ func (c Color) String() string {
 	case None:
 		return "None"
 	}
+	if str, ok := extendedString(c); ok {
+		return str
+	}
 	return fmt.Sprintf("Unknown Color (%d)", int(c))
 }
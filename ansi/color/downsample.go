@@ -0,0 +1,109 @@
+package color
+
+// Convert to the nearest colour in the 256 colour palette
+// Standard and indexed colours are unchanged
+func (c Color) ToIndexed() Color {
+	if c.IsRGB() {
+		return Indexed(nearestIndexed(c.RGB()))
+	}
+	return c
+}
+
+// Convert to the nearest of the 16 standard colours
+// Standard colours are unchanged
+func (c Color) ToStandard() Color {
+	if c.IsIndexed() && c.Index() < 16 {
+		return standardColors[c.Index()]
+	}
+	if c.IsIndexed() || c.IsRGB() {
+		r, g, b := c.toRGB()
+		best := 0
+		bestDistance := -1
+		for i := 0; i < 16; i++ {
+			if distance := rgbDistance(r, g, b, paletteRGB(uint8(i))); bestDistance < 0 || distance < bestDistance {
+				best, bestDistance = i, distance
+			}
+		}
+		return standardColors[best]
+	}
+	return c
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Standard colours in palette order (0-15)
+var standardColors = []Color{
+	Black, Red, Green, Yellow, Blue, Magenta, Cyan, LightGrey,
+	DarkGrey, BrightRed, BrightGreen, BrightYellow, BrightBlue, BrightMagenta, BrightCyan, White,
+}
+
+// Typical (xterm) values for the standard colours
+var standardRGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// Levels of the 6x6x6 colour cube (16-231)
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+func (c Color) toRGB() (r, g, b uint8) {
+	if c.IsIndexed() {
+		rgb := paletteRGB(c.Index())
+		return rgb[0], rgb[1], rgb[2]
+	}
+	return c.RGB()
+}
+
+func paletteRGB(index uint8) [3]uint8 {
+	switch {
+	case index < 16:
+		return standardRGB[index]
+	case index < 232:
+		i := index - 16
+		return [3]uint8{cubeLevels[i/36], cubeLevels[(i/6)%6], cubeLevels[i%6]}
+	default:
+		grey := 8 + 10*(index-232)
+		return [3]uint8{grey, grey, grey}
+	}
+}
+
+// Nearest colour from the cube or grey ramp (the standard colours vary between terminals)
+func nearestIndexed(r, g, b uint8) uint8 {
+	cube := 16 + 36*nearestCubeLevel(r) + 6*nearestCubeLevel(g) + nearestCubeLevel(b)
+	avg := (int(r) + int(g) + int(b)) / 3
+	greyStep := (avg - 3) / 10
+	if greyStep < 0 {
+		greyStep = 0
+	} else if greyStep > 23 {
+		greyStep = 23
+	}
+	grey := uint8(232 + greyStep)
+	if rgbDistance(r, g, b, paletteRGB(grey)) < rgbDistance(r, g, b, paletteRGB(cube)) {
+		return grey
+	}
+	return cube
+}
+
+func nearestCubeLevel(val uint8) uint8 {
+	best := uint8(0)
+	for i, level := range cubeLevels {
+		if absDiff(val, level) < absDiff(val, cubeLevels[best]) {
+			best = uint8(i)
+		}
+	}
+	return best
+}
+
+func rgbDistance(r, g, b uint8, rgb [3]uint8) int {
+	dr, dg, db := absDiff(r, rgb[0]), absDiff(g, rgb[1]), absDiff(b, rgb[2])
+	return dr*dr + dg*dg + db*db
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
@@ -0,0 +1,54 @@
+package color
+
+import (
+	"fmt"
+)
+
+// Create a colour from the 256 colour palette
+func Indexed(index uint8) Color {
+	return Color(indexedFlag | int(index))
+}
+
+// Is this a colour from the 256 colour palette
+func (c Color) IsIndexed() bool {
+	return int(c)&^0xff == indexedFlag
+}
+
+// Palette index of an indexed colour
+func (c Color) Index() uint8 {
+	return uint8(int(c) & 0xff)
+}
+
+// Create a 24 bit (truecolor) colour
+func RGB(r, g, b uint8) Color {
+	return Color(rgbFlag | int(r)<<16 | int(g)<<8 | int(b))
+}
+
+// Is this a 24 bit (truecolor) colour
+func (c Color) IsRGB() bool {
+	return int(c)&^0xffffff == rgbFlag
+}
+
+// Red, green and blue components of a 24 bit colour
+func (c Color) RGB() (r, g, b uint8) {
+	return uint8(int(c) >> 16), uint8(int(c) >> 8), uint8(int(c))
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Extended colours are encoded above the range of the standard codes
+const indexedFlag = 0x100
+const rgbFlag = 0x1000000
+
+func extendedString(c Color) (str string, ok bool) {
+	if c.IsIndexed() {
+		return fmt.Sprintf("Indexed(%d)", c.Index()), true
+	}
+	if c.IsRGB() {
+		r, g, b := c.RGB()
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b), true
+	}
+	return "", false
+}
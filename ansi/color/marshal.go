@@ -0,0 +1,45 @@
+package color
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (c Color) MarshalText() ([]byte, error) {
+	if _, err := ParseColor(c.String()); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid color: %d", int(c)))
+	}
+	return []byte(c.String()), nil
+}
+
+func (c *Color) UnmarshalText(text []byte) error {
+	col, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = col
+	return nil
+}
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	text, err := c.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// Accepts a string or a numeric code
+func (c *Color) UnmarshalJSON(data []byte) error {
+	// null leaves the value unchanged
+	if string(data) == "null" {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		str = strings.TrimSpace(string(data))
+	}
+	return c.UnmarshalText([]byte(str))
+}
@@ -0,0 +1,99 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Tolerant parsing
+// accepts names (as String(), ignoring case, words may be separated by spaces, '-' or '_', grey or gray),
+// SGR codes (30-37, 90-97 or background 40-47, 100-107), indexed(n), #rrggbb and rgb(r,g,b)
+func ParseColor(str string) (col Color, err error) {
+	if col, ok := colorNames[normaliseName(str)]; ok {
+		return col, nil
+	}
+	// Codes and functions (ignoring spaces)
+	name := strings.ReplaceAll(strings.ToLower(str), " ", "")
+	if matches := indexedRegExp.FindStringSubmatch(name); matches != nil {
+		if index, err := strconv.Atoi(matches[1]); err == nil && index <= 255 {
+			return Indexed(uint8(index)), nil
+		}
+	}
+	if matches := hexRegExp.FindStringSubmatch(name); matches != nil {
+		val, _ := strconv.ParseUint(matches[1], 16, 32)
+		return RGB(uint8(val>>16), uint8(val>>8), uint8(val)), nil
+	}
+	if matches := rgbRegExp.FindStringSubmatch(name); matches != nil {
+		r, errR := strconv.Atoi(matches[1])
+		g, errG := strconv.Atoi(matches[2])
+		b, errB := strconv.Atoi(matches[3])
+		if errR == nil && errG == nil && errB == nil && r <= 255 && g <= 255 && b <= 255 {
+			return RGB(uint8(r), uint8(g), uint8(b)), nil
+		}
+	}
+	if code, err := strconv.Atoi(name); err == nil {
+		if isStandardCode(code) {
+			return Color(code), nil
+		}
+		if isStandardCode(code - 10) {
+			return Color(code - 10), nil
+		}
+	}
+	return None, errors.New(fmt.Sprintf("invalid color: %s", str))
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+var indexedRegExp = regexp.MustCompile(`^(?:indexed|color|colour)\((\d{1,3})\)$`)
+var hexRegExp = regexp.MustCompile(`^#([0-9a-f]{6})$`)
+var rgbRegExp = regexp.MustCompile(`^rgb\((\d{1,3}),(\d{1,3}),(\d{1,3})\)$`)
+
+var colorNames = createColorNames()
+
+func createColorNames() map[string]Color {
+	names := make(map[string]Color)
+	// Aliases
+	addColorName(names, None, "default")
+	addColorName(names, DarkGrey, "grey")
+	addColorName(names, DarkGrey, "bright", "black")
+	addColorName(names, White, "bright", "white")
+	for _, col := range append(standardColors, None) {
+		addColorName(names, col, splitWords(col.String())...)
+	}
+	return names
+}
+
+// Name with words run together or separated
+func addColorName(names map[string]Color, col Color, words ...string) {
+	names[strings.Join(words, "")] = col
+	names[strings.Join(words, " ")] = col
+}
+
+// Split CamelCase name into lower case words
+func splitWords(name string) []string {
+	words := make([]string, 0, 2)
+	start := 0
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(name[start:]))
+}
+
+// Lower case words separated by single spaces
+func normaliseName(str string) string {
+	words := strings.FieldsFunc(strings.ToLower(str), func(r rune) bool { return r == ' ' || r == '-' || r == '_' })
+	return strings.ReplaceAll(strings.Join(words, " "), "gray", "grey")
+}
+
+func isStandardCode(code int) bool {
+	return (30 <= code && code <= 37) || (90 <= code && code <= 97)
+}
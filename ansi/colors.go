@@ -29,6 +29,11 @@ func toForegroundColor(val int) (newCol color.Color, success bool) {
 // ----------------------------------------------------------------------------------------------------------------------------
 var resetColorCode = 39
 
+// Extended colours (38;5;n or 38;2;r;g;b), background is converted as standard colours (48;...)
+var extendedColorCode = 38
+var indexedColorMode = 5
+var rgbColorMode = 2
+
 func isForegroundColor(col color.Color) bool {
 	val := int(col)
 	return (30 <= val && val <= 37) || (90 <= val && val <= 97)
@@ -42,3 +47,49 @@ func convertBackgroundToForegroundColor(col color.Color) color.Color {
 func convertForegroundToBackgroundColor(col color.Color) color.Color {
 	return color.Color(int(col) + 10)
 }
+
+// Codes to set a colour (as foreground)
+func colorCodes(col color.Color) []int {
+	if col.IsIndexed() {
+		return []int{extendedColorCode, indexedColorMode, int(col.Index())}
+	}
+	if col.IsRGB() {
+		r, g, b := col.RGB()
+		return []int{extendedColorCode, rgbColorMode, int(r), int(g), int(b)}
+	}
+	return []int{int(col)}
+}
+
+// Parse the parameters of an extended colour (following 38 or 48)
+// Returns the number of codes consumed
+func parseExtendedColor(codes []int) (col color.Color, length int, ok bool) {
+	if len(codes) == 0 {
+		return color.None, 0, false
+	}
+	// Malformed colours consume the mode and its arguments (as far as present) so following codes still apply
+	switch codes[0] {
+	case indexedColorMode:
+		if len(codes) > 1 && isColorComponent(codes[1]) {
+			return color.Indexed(uint8(codes[1])), 2, true
+		}
+		return color.None, minInt(len(codes), 2), false
+	case rgbColorMode:
+		if len(codes) > 3 && isColorComponent(codes[1]) && isColorComponent(codes[2]) && isColorComponent(codes[3]) {
+			return color.RGB(uint8(codes[1]), uint8(codes[2]), uint8(codes[3])), 4, true
+		}
+		return color.None, minInt(len(codes), 4), false
+	}
+	// Unknown mode, ignore the mode only
+	return color.None, 1, false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func isColorComponent(val int) bool {
+	return 0 <= val && val <= 255
+}
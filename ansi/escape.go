@@ -0,0 +1,87 @@
+package ansi
+
+// Form of the control sequence introducer (CSI)
+type EscapeForm int
+
+const (
+	// ESC [ (widely supported)
+	Escape7Bit EscapeForm = 0
+	// Single byte 0x9b
+	Escape8Bit EscapeForm = 1
+)
+
+func (f EscapeForm) String() string {
+	switch f {
+	case Escape7Bit:
+		return "7Bit"
+	case Escape8Bit:
+		return "8Bit"
+	}
+	return "Unknown EscapeForm"
+}
+
+// Set the form of CSI emitted (default is 7 bit)
+// Parsing always accepts both forms
+func SetEscapeForm(form EscapeForm) {
+	escapeForm = form
+}
+
+func GetEscapeForm() EscapeForm {
+	return escapeForm
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+var escapeForm = Escape7Bit
+
+var escapeChar = '\x1b'
+var escape = '\x9b'
+var oscChar = '\x9d'
+var stChar = '\x9c'
+var belChar = '\x07'
+
+func getCsi() string {
+	if escapeForm == Escape8Bit {
+		return string(escape)
+	}
+	return string([]rune{escapeChar, '['})
+}
+
+// Operating system command introducer
+func getOsc() string {
+	if escapeForm == Escape8Bit {
+		return string(oscChar)
+	}
+	return string([]rune{escapeChar, ']'})
+}
+
+// String terminator
+func getSt() string {
+	if escapeForm == Escape8Bit {
+		return string(stChar)
+	}
+	return string([]rune{escapeChar, '\\'})
+}
+
+// Check for CSI (either form) at idx and move past it
+func parseCsi(str []rune, idx *int) bool {
+	return parseIntroducer(str, idx, escape, '[')
+}
+
+// Check for OSC (either form) at idx and move past it
+func parseOsc(str []rune, idx *int) bool {
+	return parseIntroducer(str, idx, oscChar, ']')
+}
+
+func parseIntroducer(str []rune, idx *int, char8Bit rune, char7Bit rune) bool {
+	if str[*idx] == char8Bit {
+		*idx++
+		return true
+	}
+	if str[*idx] == escapeChar && *idx+1 < len(str) && str[*idx+1] == char7Bit {
+		*idx += 2
+		return true
+	}
+	return false
+}
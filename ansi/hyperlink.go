@@ -0,0 +1,33 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// Wrap text in an OSC 8 hyperlink
+func Hyperlink(url string, text string) string {
+	str := strings.Builder{}
+	str.WriteString(HyperlinkCode(url))
+	str.WriteString(text)
+	str.WriteString(HyperlinkCode(""))
+	return str.String()
+}
+
+// Get the OSC 8 code to start a hyperlink (or end it if url is empty)
+func HyperlinkCode(url string) string {
+	return OperatingSystemCommand{hyperlinkCommand + ";;" + url}.String()
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+var hyperlinkCommand = "8"
+
+// Get url from OSC 8 payload (8;params;url)
+func parseHyperlink(payload string) (url string, ok bool) {
+	parts := strings.SplitN(payload, ";", 3)
+	if len(parts) == 3 && parts[0] == hyperlinkCommand {
+		return parts[2], true
+	}
+	return "", false
+}
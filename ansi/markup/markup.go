@@ -0,0 +1,124 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+)
+
+// Render markup to a string with escape sequences
+//
+//	[red on blue]text[/]      colours (foreground, "on" background), see ansi.ParseAttributes
+//	[bold underline]text[/]   styles (bold, dim, italic, underline, blink, reverse, hidden, strikethrough)
+//	[link=url]text[/]         hyperlink
+//	[/tag] closes the matching tag, [/] the most recent
+//	[[ and ]] are literal brackets
+//
+// Tags nest (inheriting the enclosing attributes) and are closed at the end of the markup
+func Render(markup string) (string, error) {
+	parts, err := Parse(markup)
+	if err != nil {
+		return "", err
+	}
+	return ansi.NewAttributedText(parts...).String(), nil
+}
+
+func MustRender(markup string) string {
+	str, err := Render(markup)
+	if err != nil {
+		panic(err)
+	}
+	return str
+}
+
+// Parse markup into text with attributes
+func Parse(markup string) ([]ansi.AttributeString, error) {
+	p := parser{current: openTag{attributes: ansi.NoAttributes}}
+	str := []rune(markup)
+	for idx := 0; idx < len(str); idx++ {
+		switch {
+		case str[idx] == '[' && idx+1 < len(str) && str[idx+1] == '[':
+			p.text.WriteRune('[')
+			idx++
+		case str[idx] == ']' && idx+1 < len(str) && str[idx+1] == ']':
+			p.text.WriteRune(']')
+			idx++
+		case str[idx] == '[':
+			end := indexRune(str, idx+1, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed tag at position %d", idx)
+			}
+			if err := p.tag(string(str[idx+1:end]), idx); err != nil {
+				return nil, err
+			}
+			idx = end
+		default:
+			p.text.WriteRune(str[idx])
+		}
+	}
+	p.flushText()
+	return p.parts, nil
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+type openTag struct {
+	tag        string
+	attributes ansi.Attributes
+	link       string
+}
+
+type parser struct {
+	current openTag
+	stack   []openTag
+	text    strings.Builder
+	parts   []ansi.AttributeString
+}
+
+func (p *parser) tag(tag string, pos int) error {
+	tag = strings.TrimSpace(tag)
+	if strings.HasPrefix(tag, "/") {
+		return p.closeTag(strings.TrimSpace(tag[1:]), pos)
+	}
+	newTag, err := applyTag(p.current, tag)
+	if err != nil {
+		return fmt.Errorf("invalid tag [%s] at position %d: %v", tag, pos, err)
+	}
+	p.flushText()
+	p.stack = append(p.stack, p.current)
+	p.current = newTag
+	return nil
+}
+
+func (p *parser) closeTag(tag string, pos int) error {
+	if len(p.stack) == 0 {
+		return fmt.Errorf("close tag [/%s] at position %d has no open tag", tag, pos)
+	}
+	if tag != "" && tag != p.current.tag {
+		return fmt.Errorf("close tag [/%s] at position %d does not match open tag [%s]", tag, pos, p.current.tag)
+	}
+	p.flushText()
+	last := len(p.stack) - 1
+	p.current = p.stack[last]
+	p.stack = p.stack[:last]
+	return nil
+}
+
+func (p *parser) flushText() {
+	if p.text.Len() > 0 {
+		p.parts = append(p.parts, ansi.AttributeString{String: p.text.String(), Attributes: p.current.attributes, Link: p.current.link})
+		p.text.Reset()
+	}
+}
+
+func indexRune(str []rune, start int, r rune) int {
+	for i := start; i < len(str); i++ {
+		if str[i] == r {
+			return i
+		}
+	}
+	return -1
+}
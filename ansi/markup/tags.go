@@ -0,0 +1,57 @@
+package markup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+)
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Apply the words of a tag to the enclosing attributes
+func applyTag(enclosing openTag, tag string) (openTag, error) {
+	newTag := enclosing
+	newTag.tag = tag
+	words := strings.Fields(tag)
+	if len(words) == 0 {
+		return newTag, errors.New("empty tag")
+	}
+	attributeWords := make([]string, 0, len(words))
+	for _, word := range words {
+		if strings.HasPrefix(strings.ToLower(word), "link=") {
+			newTag.link = word[len("link="):]
+		} else {
+			attributeWords = append(attributeWords, word)
+		}
+	}
+	attributes, err := ansi.ParseAttributesFrom(enclosing.attributes, strings.Join(attributeWords, " "))
+	if err != nil {
+		return newTag, tagError(attributeWords, err)
+	}
+	newTag.attributes = attributes
+	return newTag, nil
+}
+
+// Error for the words of a tag that failed to parse
+// Names the word following the longest valid start of the tag (if the words are valid but don't go together the error is returned unchanged)
+func tagError(words []string, err error) error {
+	valid := 0
+	for i := range words {
+		if _, prefixErr := ansi.ParseAttributes(strings.Join(words[:i+1], " ")); prefixErr == nil {
+			valid = i + 1
+		}
+	}
+	if valid < len(words) {
+		if strings.EqualFold(words[valid], "on") {
+			return errors.New("missing background colour after 'on'")
+		}
+		if _, wordErr := ansi.ParseAttributes(words[valid]); wordErr != nil {
+			return fmt.Errorf("unknown colour or style '%s'", words[valid])
+		}
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+package ansi
+
+import (
+	"encoding/json"
+)
+
+func (a Attributes) MarshalText() ([]byte, error) {
+	if _, err := a.Foreground.MarshalText(); err != nil {
+		return nil, err
+	}
+	if _, err := a.Background.MarshalText(); err != nil {
+		return nil, err
+	}
+	return []byte(a.String()), nil
+}
+
+func (a *Attributes) UnmarshalText(text []byte) error {
+	attributes, err := ParseAttributes(string(text))
+	if err != nil {
+		return err
+	}
+	*a = attributes
+	return nil
+}
+
+func (a Attributes) MarshalJSON() ([]byte, error) {
+	text, err := a.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	// null leaves the value unchanged
+	if string(data) == "null" {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return a.UnmarshalText([]byte(str))
+}
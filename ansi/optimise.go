@@ -0,0 +1,62 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// Re-emit string with the fewest SGR bytes
+// Redundant codes are removed and each change uses either a delta or a full reset, whichever is shorter
+// Other escape sequences are preserved, with any change in attributes before them written first
+// (e.g. erase uses the current background)
+func Optimise(str string) string {
+	tok := tokenizer{attributes: NoAttributes}
+	tok.parse([]rune(str), true)
+	tok.flushText()
+	text := strings.Builder{}
+	current := NoAttributes
+	link := ""
+	for i, token := range tok.tokens {
+		state := tok.states[i]
+		text.WriteString(optimalLinkChange(link, state.link))
+		link = state.link
+		text.WriteString(optimalDelta(current, state.attributes))
+		current = state.attributes
+		switch t := token.(type) {
+		case AttributeString:
+			text.WriteString(t.String)
+		case ControlSequence:
+			text.WriteString(t.String())
+		case EscapeSequence:
+			text.WriteString(t.String())
+		case OperatingSystemCommand:
+			text.WriteString(t.String())
+		}
+	}
+	// Leave in the same final state
+	text.WriteString(optimalLinkChange(link, tok.link))
+	text.WriteString(optimalDelta(current, tok.attributes))
+	return text.String()
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func optimalDelta(oldAttribs, newAttribs Attributes) string {
+	if oldAttribs == newAttribs {
+		return ""
+	}
+	delta := oldAttribs.CreateDeltaTo(newAttribs).GetCodeString()
+	reset := newAttributeChange(append([]int{resetAllCode}, newAttribs.SetThis().GetCodes()...)).GetCodeString()
+	if len(reset) < len(delta) {
+		return reset
+	}
+	return delta
+}
+
+func optimalLinkChange(oldLink, newLink string) string {
+	if oldLink == newLink {
+		return ""
+	}
+	return HyperlinkCode(newLink)
+}
@@ -0,0 +1,59 @@
+package ansi
+
+import (
+	"strings"
+)
+
+type Alignment int
+
+const (
+	AlignLeft   Alignment = 0
+	AlignRight  Alignment = 1
+	AlignCentre Alignment = 2
+)
+
+func (a Alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "Left"
+	case AlignRight:
+		return "Right"
+	case AlignCentre:
+		return "Centre"
+	}
+	return "Unknown Alignment"
+}
+
+// Pad string (containing escape sequences) to width cells
+// Padding is spaces with the given attributes (use NoAttributes for plain spaces)
+// Strings wider than width are unchanged, the result ends with a reset
+func Pad(str string, width int, align Alignment, padding Attributes) string {
+	return renderParts(padParts(ParseString(str), width, align, padding))
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func padParts(parts []AttributeString, width int, align Alignment, padding Attributes) []AttributeString {
+	extra := width - partsWidth(parts)
+	if extra <= 0 {
+		return parts
+	}
+	left := 0
+	switch align {
+	case AlignRight:
+		left = extra
+	case AlignCentre:
+		left = extra / 2
+	}
+	padded := make([]AttributeString, 0, len(parts)+2)
+	if left > 0 {
+		padded = append(padded, AttributeString{String: strings.Repeat(" ", left), Attributes: padding})
+	}
+	padded = append(padded, parts...)
+	if right := extra - left; right > 0 {
+		padded = append(padded, AttributeString{String: strings.Repeat(" ", right), Attributes: padding})
+	}
+	return padded
+}
@@ -4,61 +4,305 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/atrico-go/console/ansi/color"
 )
 
 type AttributeString struct {
 	String     string
 	Attributes Attributes
+	// Hyperlink target (OSC 8)
+	Link string
+}
+
+// Control sequence other than SGR (e.g. cursor movement, erase, private modes)
+type ControlSequence struct {
+	// Parameter bytes including any private marker (e.g. "?25")
+	Parameters string
+	// Intermediate bytes
+	Intermediate string
+	// Final byte (e.g. 'H')
+	Final rune
+}
+
+func (c ControlSequence) String() string {
+	return getCsi() + c.Parameters + c.Intermediate + string(c.Final)
+}
+
+// Escape sequence other than CSI (e.g. ESC 7, ESC ( 0)
+type EscapeSequence struct {
+	// Intermediate bytes
+	Intermediate string
+	// Final byte
+	Final rune
+}
+
+func (e EscapeSequence) String() string {
+	return string(escapeChar) + e.Intermediate + string(e.Final)
+}
+
+// Operating system command other than hyperlinks (e.g. window title)
+type OperatingSystemCommand struct {
+	Payload string
 }
 
+func (o OperatingSystemCommand) String() string {
+	return getOsc() + o.Payload + getSt()
+}
+
+// Token from Tokenize
+// AttributeString, ControlSequence, EscapeSequence or OperatingSystemCommand
+type Token interface {
+	token()
+}
+
+func (AttributeString) token()        {}
+func (ControlSequence) token()        {}
+func (EscapeSequence) token()         {}
+func (OperatingSystemCommand) token() {}
+
+// Parse string into text with attributes
+// Other escape sequences are removed
 func ParseString(str string) []AttributeString {
 	parts := make([]AttributeString, 0, 1)
-	currentAttributes := NoAttributes
-	currentPart := strings.Builder{}
-	idx := 0
-	strR := []rune(str)
-	for idx < len(strR) {
-		if strR[idx] == escape {
-			idx++
-			codes := parseAttributes(strR, &idx)
-			change := newAttributeChange(codes)
-			newAttrs := currentAttributes.Modify(change)
-			if newAttrs != currentAttributes {
-				if currentPart.Len() > 0 {
-					parts = append(parts, AttributeString{currentPart.String(), currentAttributes})
-					currentPart.Reset()
-				}
-				currentAttributes = newAttrs
+	for _, token := range Tokenize(str) {
+		if part, ok := token.(AttributeString); ok {
+			// Merge text split by other sequences
+			if last := len(parts) - 1; last >= 0 && parts[last].Attributes == part.Attributes && parts[last].Link == part.Link {
+				parts[last].String += part.String
+			} else {
+				parts = append(parts, part)
 			}
-		} else {
-			currentPart.WriteString(string(strR[idx]))
-			idx++
 		}
 	}
-	// Write last currentPart
-	if currentPart.Len() > 0 {
-		parts = append(parts, AttributeString{currentPart.String(), currentAttributes})
-	}
 	return parts
 }
 
+// Parse string into text with attributes/links and other escape sequences
+// An incomplete sequence at the end of the string is discarded
+func Tokenize(str string) []Token {
+	tok := tokenizer{attributes: NoAttributes}
+	tok.parse([]rune(str), true)
+	tok.flushText()
+	return tok.tokens
+}
+
 // ----------------------------------------------------------------------------------------------------------------------------
 // internal
 // ----------------------------------------------------------------------------------------------------------------------------
 
-var regExp = regexp.MustCompile(`(\d+)(?:;(\d+))*m`)
+type tokenizer struct {
+	attributes Attributes
+	link       string
+	text       strings.Builder
+	tokens     []Token
+	// Attributes and link active at each token
+	states []tokenState
+}
+
+type tokenState struct {
+	attributes Attributes
+	link       string
+}
+
+// Parse runes, returns the number consumed
+// If not final, an incomplete sequence at the end is not consumed
+func (t *tokenizer) parse(str []rune, final bool) int {
+	idx := 0
+	for idx < len(str) {
+		start := idx
+		switch {
+		case parseCsi(str, &idx):
+			seq, complete := scanControlSequence(str, &idx)
+			if !complete {
+				return incomplete(start, len(str), final)
+			}
+			t.control(seq)
+		case parseOsc(str, &idx):
+			osc, complete := scanOperatingSystemCommand(str, &idx)
+			if !complete {
+				return incomplete(start, len(str), final)
+			}
+			t.operatingSystemCommand(osc)
+		case str[idx] == escapeChar:
+			idx++
+			seq, complete := scanEscapeSequence(str, &idx)
+			if !complete {
+				return incomplete(start, len(str), final)
+			}
+			if seq.Final != 0 {
+				t.flushText()
+				t.addToken(seq)
+			}
+		default:
+			t.text.WriteRune(str[idx])
+			idx++
+		}
+	}
+	return idx
+}
+
+func incomplete(start, length int, final bool) int {
+	if final {
+		return length
+	}
+	return start
+}
+
+func (t *tokenizer) control(seq ControlSequence) {
+	// Invalid sequence
+	if seq.Final == 0 {
+		return
+	}
+	// SGR
+	if seq.Final == 'm' && seq.Intermediate == "" && regExp.MatchString(seq.Parameters) {
+		newAttrs := t.attributes.Modify(newAttributeChange(parseAttributes(seq.Parameters)))
+		if newAttrs != t.attributes {
+			t.flushText()
+			t.attributes = newAttrs
+		}
+		return
+	}
+	t.flushText()
+	t.addToken(seq)
+}
+
+func (t *tokenizer) operatingSystemCommand(osc OperatingSystemCommand) {
+	if url, ok := parseHyperlink(osc.Payload); ok {
+		if url != t.link {
+			t.flushText()
+			t.link = url
+		}
+		return
+	}
+	t.flushText()
+	t.addToken(osc)
+}
+
+func (t *tokenizer) flushText() {
+	if t.text.Len() > 0 {
+		t.addToken(AttributeString{t.text.String(), t.attributes, t.link})
+		t.text.Reset()
+	}
+}
+
+func (t *tokenizer) addToken(token Token) {
+	t.tokens = append(t.tokens, token)
+	t.states = append(t.states, tokenState{t.attributes, t.link})
+}
+
+// Scan CSI sequence (following the CSI)
+// An invalid character ends the sequence (without consuming it) and returns Final == 0
+func scanControlSequence(str []rune, idx *int) (seq ControlSequence, complete bool) {
+	start := *idx
+	for *idx < len(str) && isParameterByte(str[*idx]) {
+		*idx++
+	}
+	seq.Parameters = string(str[start:*idx])
+	start = *idx
+	for *idx < len(str) && isIntermediateByte(str[*idx]) {
+		*idx++
+	}
+	seq.Intermediate = string(str[start:*idx])
+	if *idx >= len(str) {
+		return seq, false
+	}
+	if isFinalByte(str[*idx]) {
+		seq.Final = str[*idx]
+		*idx++
+	}
+	return seq, true
+}
+
+// Scan escape sequence (following the ESC)
+// An invalid character ends the sequence (without consuming it) and returns Final == 0
+func scanEscapeSequence(str []rune, idx *int) (seq EscapeSequence, complete bool) {
+	start := *idx
+	for *idx < len(str) && isIntermediateByte(str[*idx]) {
+		*idx++
+	}
+	seq.Intermediate = string(str[start:*idx])
+	if *idx >= len(str) {
+		return seq, false
+	}
+	if '0' <= str[*idx] && str[*idx] <= '~' {
+		seq.Final = str[*idx]
+		*idx++
+	}
+	return seq, true
+}
+
+// Scan OSC payload (following the OSC) up to ST (either form) or BEL
+// An ESC not forming ST ends the command (without consuming it)
+func scanOperatingSystemCommand(str []rune, idx *int) (osc OperatingSystemCommand, complete bool) {
+	start := *idx
+	for ; *idx < len(str); *idx++ {
+		switch str[*idx] {
+		case belChar, stChar:
+			osc.Payload = string(str[start:*idx])
+			*idx++
+			return osc, true
+		case escapeChar:
+			if *idx+1 >= len(str) {
+				return osc, false
+			}
+			osc.Payload = string(str[start:*idx])
+			if str[*idx+1] == '\\' {
+				*idx += 2
+			}
+			return osc, true
+		}
+	}
+	return osc, false
+}
+
+func isParameterByte(r rune) bool {
+	return '0' <= r && r <= '?'
+}
+
+func isIntermediateByte(r rune) bool {
+	return ' ' <= r && r <= '/'
+}
 
-func parseAttributes(str []rune, idx *int) (attributes []int) {
+func isFinalByte(r rune) bool {
+	return '@' <= r && r <= '~'
+}
+
+// SGR parameters are separated by ';', extended colours may also use ':' sub parameters (38:2::r:g:b)
+// Empty parameters default to 0 (reset)
+var regExp = regexp.MustCompile(`^[\d;:]*$`)
+
+func parseAttributes(params string) (attributes []int) {
 	attributes = make([]int, 0)
-	strS := string(str[*idx:])
-	matches := regExp.FindAllStringSubmatch(strS, 1)
-	if matches != nil {
-		*idx = *idx + len(matches[0][0])
-		for i := 1; i < len(matches[0]); i++ {
-			if val, err := strconv.Atoi(matches[0][i]); err == nil {
+	for _, param := range strings.Split(params, ";") {
+		for _, subParam := range splitSubParameters(param) {
+			if subParam == "" {
+				subParam = "0"
+			}
+			if val, err := strconv.Atoi(subParam); err == nil {
 				attributes = append(attributes, val)
 			}
 		}
 	}
 	return attributes
 }
+
+// Split ':' separated sub parameters, dropping the colour space id from 38:2:id:r:g:b
+// Underline style 4:0 is underline off (24), other styles (4:n) are underline
+// Sub parameters of other codes are ignored
+func splitSubParameters(param string) []string {
+	subParams := strings.Split(param, ":")
+	if subParams[0] == strconv.Itoa(underlineCode) && len(subParams) > 1 {
+		if val, err := strconv.Atoi(subParams[1]); err == nil && val == 0 {
+			return []string{strconv.Itoa(underlineResetCode)}
+		}
+		return subParams[:1]
+	}
+	if subParams[0] != strconv.Itoa(extendedColorCode) && subParams[0] != strconv.Itoa(int(convertForegroundToBackgroundColor(color.Color(extendedColorCode)))) {
+		return subParams[:1]
+	}
+	if len(subParams) == 6 && subParams[1] == strconv.Itoa(rgbColorMode) {
+		subParams = append(subParams[:2], subParams[3:]...)
+	}
+	return subParams
+}
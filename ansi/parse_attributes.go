@@ -0,0 +1,113 @@
+package ansi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atrico-go/console/ansi/color"
+)
+
+// Tolerant parsing
+// accepts colours and styles (ignoring case) e.g. "white on red bold"
+// the first colour is foreground, the second (or one following "on") background
+// also accepts the String() form e.g. "[White,Red,Bold]"
+func ParseAttributes(str string) (attributes Attributes, err error) {
+	return ParseAttributesFrom(NoAttributes, str)
+}
+
+// Parse attributes (as ParseAttributes) modifying the base attributes
+func ParseAttributesFrom(base Attributes, str string) (attributes Attributes, err error) {
+	attributes = base
+	words := splitAttributeWords(str)
+	colors := 0
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if strings.ToLower(word) == "on" {
+			if i++; i >= len(words) {
+				return base, errors.New(fmt.Sprintf("invalid attributes: %s (missing color after 'on')", str))
+			}
+			col, length, err := parseColorWords(words[i:])
+			if err != nil {
+				return base, errors.New(fmt.Sprintf("invalid attributes: %s (%v)", str, err))
+			}
+			i += length - 1
+			attributes.Background = col
+			colors = 2
+			continue
+		}
+		if style, ok := parseStyle(word); ok {
+			*style.field(&attributes) = true
+			continue
+		}
+		col, length, err := parseColorWords(words[i:])
+		if err != nil {
+			return base, errors.New(fmt.Sprintf("invalid attributes: %s (unknown color or style: %s)", str, word))
+		}
+		i += length - 1
+		switch colors {
+		case 0:
+			attributes.Foreground = col
+		case 1:
+			attributes.Background = col
+		default:
+			return base, errors.New(fmt.Sprintf("invalid attributes: %s (too many colors)", str))
+		}
+		colors++
+	}
+	return attributes, nil
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func parseStyle(name string) (style styleCodes, ok bool) {
+	for _, style := range styles {
+		if strings.EqualFold(style.name, name) {
+			return style, true
+		}
+	}
+	return styleCodes{}, false
+}
+
+// Colour from the first word, or the first word joined with the following words (e.g. "bright red")
+// Returns the number of words used
+func parseColorWords(words []string) (col color.Color, length int, err error) {
+	col, err = color.ParseColor(words[0])
+	for length = 1; err != nil && length < len(words); length++ {
+		if _, ok := parseStyle(words[length]); ok || strings.EqualFold(words[length], "on") {
+			break
+		}
+		if joined, joinedErr := color.ParseColor(strings.Join(words[:length+1], " ")); joinedErr == nil {
+			return joined, length + 1, nil
+		}
+	}
+	return col, 1, err
+}
+
+// Split on spaces, commas and brackets (except within parentheses)
+func splitAttributeWords(str string) []string {
+	words := make([]string, 0)
+	word := strings.Builder{}
+	depth := 0
+	for _, r := range str {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth <= 0 && (r == ' ' || r == '\t' || r == ',' || r == '[' || r == ']'):
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+			continue
+		}
+		word.WriteRune(r)
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+	return words
+}
@@ -0,0 +1,119 @@
+package ansi
+
+import (
+	"os"
+	"strings"
+
+	"github.com/atrico-go/console/ansi/color"
+)
+
+// Colour capability of the target terminal
+type ColorProfile int
+
+const (
+	// No colours (styles are still emitted)
+	NoColor ColorProfile = 0
+	// 16 standard colours
+	Ansi16 ColorProfile = 1
+	// 256 colour palette
+	Ansi256 ColorProfile = 2
+	// 24 bit colours
+	TrueColor ColorProfile = 3
+)
+
+func (p ColorProfile) String() string {
+	switch p {
+	case NoColor:
+		return "NoColor"
+	case Ansi16:
+		return "Ansi16"
+	case Ansi256:
+		return "Ansi256"
+	case TrueColor:
+		return "TrueColor"
+	}
+	return "Unknown ColorProfile"
+}
+
+// Set the profile used when emitting codes (default is TrueColor)
+// Richer colours are downsampled to fit the profile
+func SetColorProfile(profile ColorProfile) {
+	colorProfile = profile
+}
+
+func GetColorProfile() ColorProfile {
+	return colorProfile
+}
+
+// Detect the colour profile from the environment
+// NO_COLOR (if not empty) disables colour, otherwise COLORTERM and TERM are examined
+func DetectColorProfile() ColorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return NoColor
+	}
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	if colorTerm == "truecolor" || colorTerm == "24bit" {
+		return TrueColor
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case term == "" || term == "dumb":
+		return NoColor
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "24bit") || strings.Contains(term, "direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return Ansi256
+	}
+	return Ansi16
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+var colorProfile = TrueColor
+
+// Downsample colour codes to fit the profile
+func downsampleCodes(codes []int, profile ColorProfile) []int {
+	if profile == TrueColor {
+		return codes
+	}
+	backgroundExtendedCode := int(convertForegroundToBackgroundColor(color.Color(extendedColorCode)))
+	newCodes := make([]int, 0, len(codes))
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		if code == extendedColorCode || code == backgroundExtendedCode {
+			col, length, ok := parseExtendedColor(codes[i+1:])
+			i += length
+			if col = downsampleColor(col, profile); ok && col != color.None {
+				colCodes := colorCodes(col)
+				if code == backgroundExtendedCode {
+					colCodes[0] = int(convertForegroundToBackgroundColor(color.Color(colCodes[0])))
+				}
+				newCodes = append(newCodes, colCodes...)
+			}
+			continue
+		}
+		if profile == NoColor && isColorCode(code) {
+			continue
+		}
+		newCodes = append(newCodes, code)
+	}
+	return newCodes
+}
+
+func downsampleColor(col color.Color, profile ColorProfile) color.Color {
+	switch profile {
+	case NoColor:
+		return color.None
+	case Ansi16:
+		return col.ToStandard()
+	case Ansi256:
+		return col.ToIndexed()
+	}
+	return col
+}
+
+func isColorCode(code int) bool {
+	col := color.Color(code)
+	return isForegroundColor(col) || isBackgroundColor(col) || code == resetColorCode || code == int(convertForegroundToBackgroundColor(color.Color(resetColorCode)))
+}
@@ -0,0 +1,63 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Render parts using deltas between attributes
+// Always ends with no attributes and no link
+func renderParts(parts []AttributeString) string {
+	text := strings.Builder{}
+	current := NoAttributes
+	link := ""
+	for _, part := range parts {
+		if part.Link != link {
+			text.WriteString(HyperlinkCode(part.Link))
+			link = part.Link
+		}
+		text.WriteString(current.CreateDeltaTo(part.Attributes).GetCodeString())
+		current = part.Attributes
+		text.WriteString(part.String)
+	}
+	if link != "" {
+		text.WriteString(HyperlinkCode(""))
+	}
+	if current != NoAttributes {
+		text.WriteString(ResetAll.GetCodeString())
+	}
+	return text.String()
+}
+
+// Displayed character with attributes
+type attributedCluster struct {
+	textCluster
+	attributes Attributes
+	link       string
+}
+
+func splitAttributedClusters(parts []AttributeString) []attributedCluster {
+	clusters := make([]attributedCluster, 0)
+	for _, part := range parts {
+		for _, cluster := range splitClusters(part.String) {
+			clusters = append(clusters, attributedCluster{cluster, part.Attributes, part.Link})
+		}
+	}
+	return clusters
+}
+
+// Join characters into parts, merging those with the same attributes
+func joinAttributedClusters(clusters []attributedCluster) []AttributeString {
+	parts := make([]AttributeString, 0)
+	for _, cluster := range clusters {
+		if last := len(parts) - 1; last >= 0 && parts[last].Attributes == cluster.attributes && parts[last].Link == cluster.link {
+			parts[last].String += cluster.text
+		} else {
+			parts = append(parts, AttributeString{cluster.text, cluster.attributes, cluster.link})
+		}
+	}
+	return parts
+}
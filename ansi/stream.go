@@ -0,0 +1,106 @@
+package ansi
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Incremental parser
+// Bytes are written in chunks (sequences and runes may be split across writes)
+// Tokens are passed to the handler as they complete, text is passed at the end of each write
+// so consecutive AttributeStrings may have the same attributes
+type StreamParser struct {
+	handler func(token Token)
+	tok     tokenizer
+	pending []byte
+}
+
+func NewStreamParser(handler func(token Token)) *StreamParser {
+	return &StreamParser{handler: handler, tok: tokenizer{attributes: NoAttributes}}
+}
+
+// Current attributes
+func (p *StreamParser) Attributes() Attributes {
+	return p.tok.attributes
+}
+
+// Current hyperlink target
+func (p *StreamParser) Link() string {
+	return p.tok.link
+}
+
+func (p *StreamParser) Write(data []byte) (n int, err error) {
+	p.pending = append(p.pending, data...)
+	runes, length := decodeFullRunes(p.pending)
+	consumed := p.tok.parse(runes, false)
+	// Keep incomplete sequence and rune
+	remaining := []byte(string(runes[consumed:]))
+	p.pending = append(remaining, p.pending[length:]...)
+	p.emit()
+	return len(data), nil
+}
+
+// Parse remaining data, an incomplete sequence is discarded
+func (p *StreamParser) Close() error {
+	p.tok.parse([]rune(string(p.pending)), true)
+	p.pending = nil
+	p.emit()
+	return nil
+}
+
+// Read tokens from a reader
+type TokenReader struct {
+	reader io.Reader
+	parser *StreamParser
+	tokens []Token
+	buffer []byte
+	err    error
+}
+
+func NewTokenReader(reader io.Reader) *TokenReader {
+	tr := &TokenReader{reader: reader, buffer: make([]byte, 4096)}
+	tr.parser = NewStreamParser(func(token Token) { tr.tokens = append(tr.tokens, token) })
+	return tr
+}
+
+// Get next token, returns io.EOF after the last token
+func (r *TokenReader) Next() (Token, error) {
+	for len(r.tokens) == 0 {
+		if r.err != nil {
+			return nil, r.err
+		}
+		n, err := r.reader.Read(r.buffer)
+		r.parser.Write(r.buffer[:n])
+		if err != nil {
+			r.parser.Close()
+			r.err = err
+		}
+	}
+	token := r.tokens[0]
+	r.tokens = r.tokens[1:]
+	return token, nil
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func (p *StreamParser) emit() {
+	p.tok.flushText()
+	for _, token := range p.tok.tokens {
+		p.handler(token)
+	}
+	p.tok.tokens = p.tok.tokens[:0]
+	p.tok.states = p.tok.states[:0]
+}
+
+// Decode runes, leaving an incomplete rune at the end
+func decodeFullRunes(data []byte) (runes []rune, length int) {
+	runes = make([]rune, 0, len(data))
+	for length < len(data) && utf8.FullRune(data[length:]) {
+		r, size := utf8.DecodeRune(data[length:])
+		runes = append(runes, r)
+		length += size
+	}
+	return runes, length
+}
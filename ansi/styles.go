@@ -0,0 +1,70 @@
+package ansi
+
+// Names of the text styles
+func StyleNames() []string {
+	names := make([]string, len(styles))
+	for i, style := range styles {
+		names[i] = style.name
+	}
+	return names
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Set/reset codes for a text style
+// Some styles share a reset code (bold and dim are both reset by 22)
+type styleCodes struct {
+	name  string
+	set   int
+	reset int
+	field func(a *Attributes) *bool
+}
+
+const (
+	underlineCode      = 4
+	underlineResetCode = 24
+)
+
+var styles = []styleCodes{
+	{"Bold", 1, 22, func(a *Attributes) *bool { return &a.Bold }},
+	{"Dim", 2, 22, func(a *Attributes) *bool { return &a.Dim }},
+	{"Italic", 3, 23, func(a *Attributes) *bool { return &a.Italic }},
+	{"Underline", underlineCode, underlineResetCode, func(a *Attributes) *bool { return &a.Underline }},
+	{"Blink", 5, 25, func(a *Attributes) *bool { return &a.Blink }},
+	{"Reverse", 7, 27, func(a *Attributes) *bool { return &a.Reverse }},
+	{"Hidden", 8, 28, func(a *Attributes) *bool { return &a.Hidden }},
+	{"Strikethrough", 9, 29, func(a *Attributes) *bool { return &a.Strikethrough }},
+}
+
+func getStyleDeltaCodes(oldAttribs, newAttribs Attributes) []int {
+	codes := make([]int, 0)
+	resets := make(map[int]bool)
+	// Resets first (a shared reset may turn off a style that is still required)
+	for _, style := range styles {
+		if *style.field(&oldAttribs) && !*style.field(&newAttribs) && !resets[style.reset] {
+			codes = append(codes, style.reset)
+			resets[style.reset] = true
+		}
+	}
+	for _, style := range styles {
+		if *style.field(&newAttribs) && (!*style.field(&oldAttribs) || resets[style.reset]) {
+			codes = append(codes, style.set)
+		}
+	}
+	return codes
+}
+
+func modifyStyle(attributes *Attributes, code int) (handled bool) {
+	for _, style := range styles {
+		if code == style.set {
+			*style.field(attributes) = true
+			handled = true
+		} else if code == style.reset {
+			*style.field(attributes) = false
+			handled = true
+		}
+	}
+	return handled
+}
@@ -0,0 +1,87 @@
+package ansi
+
+import (
+	"strings"
+)
+
+// Default tail for truncated text
+const Ellipsis = "…"
+
+// Truncate string (containing escape sequences) to width cells
+// If truncated, the tail is appended (within the width) with the attributes at the cut
+// The result ends with a reset, other escape sequences are removed
+func Truncate(str string, width int, tail string) string {
+	parts := ParseString(str)
+	if partsWidth(parts) <= width {
+		return renderParts(parts)
+	}
+	return renderParts(truncateParts(parts, width, tail))
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func partsWidth(parts []AttributeString) int {
+	width := 0
+	for _, part := range parts {
+		width += part.Width()
+	}
+	return width
+}
+
+// Truncate parts to width (including tail)
+func truncateParts(parts []AttributeString, width int, tail string) []AttributeString {
+	tailWidth := textWidth(tail)
+	if tailWidth > width {
+		// No room for text
+		tail = takeClusters(tail, width)
+		return []AttributeString{{String: tail, Attributes: NoAttributes}}
+	}
+	truncated, last := takeParts(parts, width-tailWidth)
+	if tail != "" {
+		// Tail takes the attributes at the cut
+		last.String = tail
+		if len(truncated) > 0 && truncated[len(truncated)-1].Attributes == last.Attributes && truncated[len(truncated)-1].Link == last.Link {
+			truncated[len(truncated)-1].String += tail
+		} else {
+			truncated = append(truncated, last)
+		}
+	}
+	return truncated
+}
+
+// Take parts up to width, never splitting a character
+// Returns the part containing the cut
+func takeParts(parts []AttributeString, width int) (taken []AttributeString, cut AttributeString) {
+	taken = make([]AttributeString, 0, len(parts))
+	if len(parts) > 0 {
+		cut = parts[0]
+	}
+	for _, part := range parts {
+		cut = part
+		partWidth := part.Width()
+		if partWidth > width {
+			if text := takeClusters(part.String, width); text != "" {
+				taken = append(taken, AttributeString{text, part.Attributes, part.Link})
+			}
+			return taken, cut
+		}
+		taken = append(taken, part)
+		width -= partWidth
+	}
+	return taken, cut
+}
+
+// Take characters up to width
+func takeClusters(str string, width int) string {
+	text := strings.Builder{}
+	for _, cluster := range splitClusters(str) {
+		if cluster.width > width {
+			break
+		}
+		text.WriteString(cluster.text)
+		width -= cluster.width
+	}
+	return text.String()
+}
@@ -0,0 +1,127 @@
+package ansi
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Remove all escape sequences from the string
+func StripString(str string) string {
+	text := strings.Builder{}
+	for _, part := range ParseString(str) {
+		text.WriteString(part.String)
+	}
+	return text.String()
+}
+
+// Display width (in terminal cells) of a string containing escape sequences
+func StringWidth(str string) int {
+	return textWidth(StripString(str))
+}
+
+// Display width (in terminal cells) of the text
+func (a AttributeString) Width() int {
+	return textWidth(a.String)
+}
+
+// Display width (in terminal cells) of a single rune
+// Wide (East Asian and emoji) runes are 2, combining marks and control characters are 0
+func RuneWidth(r rune) int {
+	switch {
+	case unicode.IsControl(r):
+		return 0
+	case r == zeroWidthJoiner || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || (0x1160 <= r && r <= 0x11ff):
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+const zeroWidthJoiner = '\u200d'
+const emojiPresentation = '\ufe0f'
+
+// A displayed character, base rune plus any combining runes
+type textCluster struct {
+	text  string
+	width int
+}
+
+// Split text into displayed characters
+// Combining marks, variation selectors, emoji modifiers and ZWJ sequences are joined to the preceding character
+func splitClusters(str string) []textCluster {
+	clusters := make([]textCluster, 0, len(str))
+	joined := false
+	for _, r := range str {
+		last := len(clusters) - 1
+		switch {
+		case last < 0:
+		case joined:
+			// Following ZWJ
+			joined = false
+			clusters[last].text += string(r)
+			continue
+		case r == zeroWidthJoiner:
+			joined = true
+			clusters[last].text += string(r)
+			continue
+		case r == emojiPresentation:
+			clusters[last].text += string(r)
+			if clusters[last].width == 1 {
+				clusters[last].width = 2
+			}
+			continue
+		case isEmojiModifier(r) && clusters[last].width == 2:
+			clusters[last].text += string(r)
+			continue
+		case RuneWidth(r) == 0 && !unicode.IsControl(r):
+			clusters[last].text += string(r)
+			continue
+		}
+		clusters = append(clusters, textCluster{string(r), RuneWidth(r)})
+	}
+	return clusters
+}
+
+func textWidth(str string) int {
+	width := 0
+	for _, cluster := range splitClusters(str) {
+		width += cluster.width
+	}
+	return width
+}
+
+func isEmojiModifier(r rune) bool {
+	return 0x1f3fb <= r && r <= 0x1f3ff
+}
+
+func inRanges(r rune, ranges [][2]rune) bool {
+	for _, rng := range ranges {
+		if r < rng[0] {
+			return false
+		}
+		if r <= rng[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// East Asian Wide/Fullwidth and emoji presentation ranges (sorted)
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec}, {0x23f0, 0x23f0}, {0x23f3, 0x23f3},
+	{0x25fd, 0x25fe}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce}, {0x26d4, 0x26d4}, {0x26ea, 0x26ea},
+	{0x26f2, 0x26f3}, {0x26f5, 0x26f5}, {0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27b0, 0x27b0}, {0x27bf, 0x27bf}, {0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf}, {0xa960, 0xa97f}, {0xac00, 0xd7a3},
+	{0xf900, 0xfaff}, {0xfe10, 0xfe19}, {0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x16fe4},
+	{0x17000, 0x18aff}, {0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e},
+	{0x1f191, 0x1f19a}, {0x1f200, 0x1f251}, {0x1f300, 0x1f64f}, {0x1f680, 0x1f6ff}, {0x1f7e0, 0x1f7eb},
+	{0x1f900, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x2fffd}, {0x30000, 0x3fffd},
+}
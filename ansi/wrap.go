@@ -0,0 +1,133 @@
+package ansi
+
+import (
+	"strings"
+	"unicode"
+)
+
+type WrapOptions struct {
+	// Break words wider than the line (otherwise they overflow)
+	HardBreak bool
+	// Indent (in cells) of wrapped lines
+	HangingIndent int
+}
+
+// Wrap string (containing escape sequences) at width cells
+// Lines break at spaces (and newlines), each line sets the active attributes and ends with a reset
+func Wrap(str string, width int, options WrapOptions) []string {
+	w := wrapper{width: width, options: options}
+	for _, cluster := range splitAttributedClusters(ParseString(str)) {
+		switch {
+		case cluster.text == "\n":
+			w.endWord()
+			w.endLine()
+			w.indent = 0
+		case isSpace(cluster.text):
+			w.endWord()
+			w.spaces = append(w.spaces, cluster)
+		default:
+			w.word = append(w.word, cluster)
+		}
+	}
+	w.endWord()
+	w.endLine()
+	return w.lines
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+type wrapper struct {
+	width   int
+	options WrapOptions
+	lines   []string
+	// Current line
+	line      []attributedCluster
+	lineWidth int
+	indent    int
+	// Pending spaces and word
+	spaces []attributedCluster
+	word   []attributedCluster
+}
+
+// Width available for text (at least one cell, so an indent wider than the line still progresses)
+func (w *wrapper) available() int {
+	if available := w.width - w.indent; available > 1 {
+		return available
+	}
+	return 1
+}
+
+func (w *wrapper) endWord() {
+	if len(w.word) == 0 {
+		return
+	}
+	if len(w.line) > 0 && w.lineWidth+clustersWidth(w.spaces)+clustersWidth(w.word) > w.available() {
+		// Wrap, discarding the spaces
+		w.endLine()
+	}
+	w.appendToLine(w.spaces)
+	if w.options.HardBreak {
+		for len(w.word) > 0 && w.lineWidth+clustersWidth(w.word) > w.available() {
+			split := splitClustersAt(w.word, w.available()-w.lineWidth)
+			if split == 0 && len(w.line) == 0 {
+				// Character wider than the line
+				split = 1
+			}
+			w.appendToLine(w.word[:split])
+			w.word = w.word[split:]
+			if len(w.word) == 0 {
+				// Character wider than the line ends the word
+				break
+			}
+			w.endLine()
+		}
+	}
+	w.appendToLine(w.word)
+	w.spaces = nil
+	w.word = nil
+}
+
+func (w *wrapper) appendToLine(clusters []attributedCluster) {
+	w.line = append(w.line, clusters...)
+	w.lineWidth += clustersWidth(clusters)
+}
+
+func (w *wrapper) endLine() {
+	text := strings.Builder{}
+	text.WriteString(strings.Repeat(" ", w.indent))
+	text.WriteString(renderParts(joinAttributedClusters(w.line)))
+	w.lines = append(w.lines, text.String())
+	w.line = nil
+	w.lineWidth = 0
+	w.spaces = nil
+	w.indent = w.options.HangingIndent
+}
+
+func clustersWidth(clusters []attributedCluster) int {
+	width := 0
+	for _, cluster := range clusters {
+		width += cluster.width
+	}
+	return width
+}
+
+// Number of clusters fitting in width
+func splitClustersAt(clusters []attributedCluster, width int) int {
+	for i, cluster := range clusters {
+		if width -= cluster.width; width < 0 {
+			return i
+		}
+	}
+	return len(clusters)
+}
+
+func isSpace(str string) bool {
+	for _, r := range str {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package ansi
+
+import (
+	"io"
+)
+
+// Writer that tracks the current attributes
+// Only the changes between attributes are written (when text is next written)
+// Text written should not contain SGR sequences
+type Writer struct {
+	writer  io.Writer
+	written Attributes
+	current Attributes
+	stack   []Attributes
+}
+
+// Create writer, the output is assumed to start with no attributes
+func NewWriter(writer io.Writer) *Writer {
+	return &Writer{writer: writer, written: NoAttributes, current: NoAttributes}
+}
+
+// Current attributes
+func (w *Writer) Attributes() Attributes {
+	return w.current
+}
+
+func (w *Writer) SetAttributes(attributes Attributes) {
+	w.current = attributes
+}
+
+// Set attributes, saving the current ones
+func (w *Writer) Push(attributes Attributes) {
+	w.stack = append(w.stack, w.current)
+	w.current = attributes
+}
+
+// Restore attributes saved by Push (no change if nothing saved)
+func (w *Writer) Pop() {
+	if last := len(w.stack) - 1; last >= 0 {
+		w.current = w.stack[last]
+		w.stack = w.stack[:last]
+	}
+}
+
+// Remove all attributes (and saved attributes)
+func (w *Writer) Reset() {
+	w.current = NoAttributes
+	w.stack = nil
+}
+
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if err = w.writeAttributes(w.current); err != nil {
+		return 0, err
+	}
+	return w.writer.Write(p)
+}
+
+func (w *Writer) WriteString(s string) (n int, err error) {
+	return w.Write([]byte(s))
+}
+
+// Restore the output to no attributes
+// Current attributes are reapplied on the next write
+func (w *Writer) Flush() error {
+	return w.writeAttributes(NoAttributes)
+}
+
+// Restore the output to no attributes and reset
+func (w *Writer) Close() error {
+	w.Reset()
+	return w.Flush()
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func (w *Writer) writeAttributes(attributes Attributes) error {
+	if code := w.written.CreateDeltaTo(attributes).GetCodeString(); code != "" {
+		if _, err := io.WriteString(w.writer, code); err != nil {
+			return err
+		}
+	}
+	w.written = attributes
+	return nil
+}
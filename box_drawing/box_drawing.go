@@ -14,6 +14,15 @@ const (
 	BoxSingle BoxType = 1
 	BoxDouble BoxType = 2
 	BoxHeavy  BoxType = 3
+	// Rounded corners (other parts as single)
+	BoxRounded BoxType = 4
+	// Dashed lines (junctions as single or heavy)
+	BoxDoubleDash         BoxType = 5
+	BoxDoubleDashHeavy    BoxType = 6
+	BoxTripleDash         BoxType = 7
+	BoxTripleDashHeavy    BoxType = 8
+	BoxQuadrupleDash      BoxType = 9
+	BoxQuadrupleDashHeavy BoxType = 10
 )
 
 func (bt BoxType) String() string {
@@ -26,15 +35,36 @@ func (bt BoxType) String() string {
 		return "Double"
 	case BoxHeavy:
 		return "Heavy"
+	case BoxRounded:
+		return "Rounded"
+	case BoxDoubleDash:
+		return "DoubleDash"
+	case BoxDoubleDashHeavy:
+		return "DoubleDashHeavy"
+	case BoxTripleDash:
+		return "TripleDash"
+	case BoxTripleDashHeavy:
+		return "TripleDashHeavy"
+	case BoxQuadrupleDash:
+		return "QuadrupleDash"
+	case BoxQuadrupleDashHeavy:
+		return "QuadrupleDashHeavy"
 	}
-	panic("Unknown box type")
+	return fmt.Sprintf("Unknown BoxType (%d)", int(bt))
+}
+
+// All box types
+func BoxTypes() []BoxType {
+	return []BoxType{BoxNone, BoxSingle, BoxDouble, BoxHeavy, BoxRounded,
+		BoxDoubleDash, BoxDoubleDashHeavy, BoxTripleDash, BoxTripleDashHeavy, BoxQuadrupleDash, BoxQuadrupleDashHeavy}
 }
 
 // Tolerant parsing
 // accepts BoxXXX or just XXX
-// Ignores case
+// Ignores case, '-' and '_'
 func ParseBoxType(str string) (bt BoxType, err error) {
-	switch strings.TrimPrefix(strings.ToLower(str), "box") {
+	name := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(str))
+	switch strings.TrimPrefix(name, "box") {
 	case "none":
 		return BoxNone,nil
 	case "single":
@@ -43,6 +73,20 @@ func ParseBoxType(str string) (bt BoxType, err error) {
 		return BoxDouble,nil
 	case "heavy":
 		return BoxHeavy,nil
+	case "rounded":
+		return BoxRounded, nil
+	case "doubledash":
+		return BoxDoubleDash, nil
+	case "doubledashheavy":
+		return BoxDoubleDashHeavy, nil
+	case "tripledash":
+		return BoxTripleDash, nil
+	case "tripledashheavy":
+		return BoxTripleDashHeavy, nil
+	case "quadrupledash":
+		return BoxQuadrupleDash, nil
+	case "quadrupledashheavy":
+		return BoxQuadrupleDashHeavy, nil
 	default:
 		return BoxNone, errors.New(fmt.Sprintf("invalid box type: %s", str))
 	}
@@ -63,9 +107,11 @@ func GetBoxChar(up bool, down bool, left bool, right bool, boxType BoxType) (cha
 	return GetBoxCharMixed(BoxParts{upBt, downBt, leftBt, rightBt})
 }
 
+// Rounded and dashed types only have glyphs for some parts (corners and straight lines respectively)
+// Otherwise they fall back to the solid type (rounded/light dashes to single, heavy dashes to heavy)
+// See GetBoxCharNearest for combinations with no character
 func GetBoxCharMixed(parts BoxParts) (char rune, ok bool) {
-	char, ok = boxParts[parts]
-	return char, ok
+	return characterSet.GetBoxCharMixed(parts)
 }
 
 func MustGetBoxChar(up bool, down bool, left bool, right bool, boxType BoxType) rune {
@@ -122,14 +168,28 @@ func (bt BoxType) HeavyIf(c bool) BoxType {
 }
 
 func Lookup(char rune) (parts BoxParts, ok bool) {
-	parts, ok = reverseLookup[char]
-	return parts, ok
+	return characterSet.Lookup(char)
 }
 
 // ----------------------------------------------------------------------------------------------------------------------------
 // Implementation
 // ----------------------------------------------------------------------------------------------------------------------------
 
+// Solid equivalent of a rounded or dashed type
+func (bt BoxType) solid() BoxType {
+	switch bt {
+	case BoxRounded, BoxDoubleDash, BoxTripleDash, BoxQuadrupleDash:
+		return BoxSingle
+	case BoxDoubleDashHeavy, BoxTripleDashHeavy, BoxQuadrupleDashHeavy:
+		return BoxHeavy
+	}
+	return bt
+}
+
+func (parts BoxParts) solid() BoxParts {
+	return BoxParts{parts.Up.solid(), parts.Down.solid(), parts.Left.solid(), parts.Right.solid()}
+}
+
 var boxParts = map[BoxParts]rune{
 	// Space
 	BoxParts{BoxNone, BoxNone, BoxNone, BoxNone}: ' ',
@@ -153,6 +213,24 @@ var boxParts = map[BoxParts]rune{
 	BoxParts{BoxSingle, BoxHeavy, BoxNone, BoxNone}:  '╽',
 	BoxParts{BoxNone, BoxNone, BoxHeavy, BoxSingle}:  '╾',
 	BoxParts{BoxNone, BoxNone, BoxSingle, BoxHeavy}:  '╼',
+	// Dashed lines
+	BoxParts{BoxDoubleDash, BoxDoubleDash, BoxNone, BoxNone}:                 '╎',
+	BoxParts{BoxNone, BoxNone, BoxDoubleDash, BoxDoubleDash}:                 '╌',
+	BoxParts{BoxDoubleDashHeavy, BoxDoubleDashHeavy, BoxNone, BoxNone}:       '╏',
+	BoxParts{BoxNone, BoxNone, BoxDoubleDashHeavy, BoxDoubleDashHeavy}:       '╍',
+	BoxParts{BoxTripleDash, BoxTripleDash, BoxNone, BoxNone}:                 '┆',
+	BoxParts{BoxNone, BoxNone, BoxTripleDash, BoxTripleDash}:                 '┄',
+	BoxParts{BoxTripleDashHeavy, BoxTripleDashHeavy, BoxNone, BoxNone}:       '┇',
+	BoxParts{BoxNone, BoxNone, BoxTripleDashHeavy, BoxTripleDashHeavy}:       '┅',
+	BoxParts{BoxQuadrupleDash, BoxQuadrupleDash, BoxNone, BoxNone}:           '┊',
+	BoxParts{BoxNone, BoxNone, BoxQuadrupleDash, BoxQuadrupleDash}:           '┈',
+	BoxParts{BoxQuadrupleDashHeavy, BoxQuadrupleDashHeavy, BoxNone, BoxNone}: '┋',
+	BoxParts{BoxNone, BoxNone, BoxQuadrupleDashHeavy, BoxQuadrupleDashHeavy}: '┉',
+	// Rounded corners
+	BoxParts{BoxRounded, BoxNone, BoxRounded, BoxNone}: '╯',
+	BoxParts{BoxRounded, BoxNone, BoxNone, BoxRounded}: '╰',
+	BoxParts{BoxNone, BoxRounded, BoxRounded, BoxNone}: '╮',
+	BoxParts{BoxNone, BoxRounded, BoxNone, BoxRounded}: '╭',
 	// Up-Left
 	BoxParts{BoxSingle, BoxNone, BoxSingle, BoxNone}: '┘',
 	BoxParts{BoxDouble, BoxNone, BoxDouble, BoxNone}: '╝',
@@ -254,12 +332,3 @@ var boxParts = map[BoxParts]rune{
 	BoxParts{BoxSingle, BoxHeavy, BoxHeavy, BoxSingle}:   '╅',
 	BoxParts{BoxHeavy, BoxSingle, BoxSingle, BoxHeavy}:   '╄',
 }
-
-var reverseLookup = make(map[rune]BoxParts, len(boxParts))
-
-func init() {
-	// Create reverse lookup
-	for parts, char := range boxParts {
-		reverseLookup[char] = parts
-	}
-}
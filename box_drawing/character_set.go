@@ -0,0 +1,313 @@
+package box_drawing
+
+import (
+	"sort"
+	"strings"
+)
+
+// Set of characters used to draw boxes
+// The package functions (GetBoxChar, Lookup, etc) use the current set (see SetCharacterSet)
+// Characters are always unicode, sets for other terminals use the glyphs the terminal can show
+// and Encode converts output for the terminal
+type CharacterSet struct {
+	name    string
+	chars   map[BoxParts]rune
+	reverse map[rune]BoxParts
+	encode  func(str string) string
+}
+
+var (
+	// Unicode box drawing block (U+2500), the default
+	UnicodeSet = NewCharacterSet("Unicode", boxParts)
+	// Plain ASCII (+ - | =)
+	AsciiSet = NewCharacterSet("Ascii", asciiParts())
+	// IBM PC code page 437 (single and double lines, heavy shown as single)
+	// Output must be encoded (see Encode)
+	Cp437Set = newEncodedCharacterSet("Cp437", cp437Parts(), encodeCp437)
+	// VT100 DEC special graphics (single lines only, double and heavy shown as single)
+	// Output must be encoded (see Encode)
+	DecSet = newEncodedCharacterSet("Dec", decParts(), encodeDec)
+)
+
+// Create a custom character set
+// Parts without a character fall back to the solid type, then to the nearest character (see GetBoxCharNearest)
+func NewCharacterSet(name string, chars map[BoxParts]rune) *CharacterSet {
+	return newEncodedCharacterSet(name, chars, nil)
+}
+
+// Set the character set used by the package functions
+func SetCharacterSet(set *CharacterSet) {
+	characterSet = set
+}
+
+// Get the character set used by the package functions
+func GetCharacterSet() *CharacterSet {
+	return characterSet
+}
+
+func (cs *CharacterSet) String() string {
+	return cs.name
+}
+
+// Encode output (may contain escape sequences) for the terminal using the current set
+// This is the only change needed at the point of output when switching to a set for another terminal
+func Encode(str string) string {
+	return characterSet.Encode(str)
+}
+
+// Encode output (may contain escape sequences) for the terminal using this set
+// Cp437: returns code page bytes (not UTF-8), characters not in the code page become '?'
+// Dec: box characters are shifted into the special graphics character set
+// Others: unchanged
+func (cs *CharacterSet) Encode(str string) string {
+	if cs.encode == nil {
+		return str
+	}
+	return cs.encode(str)
+}
+
+func (cs *CharacterSet) GetBoxCharMixed(parts BoxParts) (char rune, ok bool) {
+	if char, ok = cs.chars[parts]; ok {
+		return char, ok
+	}
+	char, ok = cs.chars[parts.solid()]
+	return char, ok
+}
+
+func (cs *CharacterSet) GetBoxCharNearest(parts BoxParts) (char rune, degradation int) {
+	return nearest(cs.chars, parts)
+}
+
+// Parts for a character
+// Where a character is used for several parts (e.g. ASCII '+') the simplest is returned
+func (cs *CharacterSet) Lookup(char rune) (parts BoxParts, ok bool) {
+	parts, ok = cs.reverse[char]
+	return parts, ok
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// Implementation
+// ----------------------------------------------------------------------------------------------------------------------------
+
+var characterSet = UnicodeSet
+
+func newEncodedCharacterSet(name string, chars map[BoxParts]rune, encode func(str string) string) *CharacterSet {
+	cs := CharacterSet{name, chars, make(map[rune]BoxParts, len(chars)), encode}
+	// Sort for a repeatable reverse lookup
+	all := make([]BoxParts, 0, len(chars))
+	for parts := range chars {
+		all = append(all, parts)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].lessThan(all[j]) })
+	for _, parts := range all {
+		char := chars[parts]
+		if existing, ok := cs.reverse[char]; !ok || parts.simplerThan(existing) {
+			cs.reverse[char] = parts
+		}
+	}
+	return &cs
+}
+
+func (parts BoxParts) all() []BoxType {
+	return []BoxType{parts.Up, parts.Down, parts.Left, parts.Right}
+}
+
+func (parts BoxParts) lessThan(rhs BoxParts) bool {
+	lhsTypes, rhsTypes := parts.all(), rhs.all()
+	for i := range lhsTypes {
+		if lhsTypes[i] != rhsTypes[i] {
+			return lhsTypes[i] < rhsTypes[i]
+		}
+	}
+	return false
+}
+
+// Prefer more parts, then fewer different types, then lighter types
+func (parts BoxParts) simplerThan(rhs BoxParts) bool {
+	lhsCount, lhsTypes, lhsWeight := parts.complexity()
+	rhsCount, rhsTypes, rhsWeight := rhs.complexity()
+	if lhsCount != rhsCount {
+		return lhsCount > rhsCount
+	}
+	if lhsTypes != rhsTypes {
+		return lhsTypes < rhsTypes
+	}
+	return lhsWeight < rhsWeight
+}
+
+func (parts BoxParts) complexity() (count int, types int, weight int) {
+	seen := make(map[BoxType]bool, 4)
+	for _, bt := range parts.all() {
+		if bt != BoxNone {
+			count++
+			weight += int(bt)
+			if !seen[bt] {
+				seen[bt] = true
+				types++
+			}
+		}
+	}
+	return count, types, weight
+}
+
+// Every combination of the given types
+func combinations(types []BoxType, char func(parts BoxParts) rune) map[BoxParts]rune {
+	chars := make(map[BoxParts]rune)
+	for _, up := range types {
+		for _, down := range types {
+			for _, left := range types {
+				for _, right := range types {
+					parts := BoxParts{up, down, left, right}
+					chars[parts] = char(parts)
+				}
+			}
+		}
+	}
+	return chars
+}
+
+func asciiParts() map[BoxParts]rune {
+	return combinations([]BoxType{BoxNone, BoxSingle, BoxDouble, BoxHeavy}, func(parts BoxParts) rune {
+		vertical := parts.Up != BoxNone || parts.Down != BoxNone
+		horizontal := parts.Left != BoxNone || parts.Right != BoxNone
+		switch {
+		case vertical && horizontal:
+			return '+'
+		case vertical:
+			return '|'
+		case parts.Left == BoxDouble || parts.Right == BoxDouble:
+			return '='
+		case horizontal:
+			return '-'
+		}
+		return ' '
+	})
+}
+
+// Box drawing characters in code page 437
+var cp437Codes = map[rune]byte{
+	'─': 0xC4, '│': 0xB3, '┌': 0xDA, '┐': 0xBF, '└': 0xC0, '┘': 0xD9, '├': 0xC3, '┤': 0xB4, '┬': 0xC2, '┴': 0xC1, '┼': 0xC5,
+	'═': 0xCD, '║': 0xBA, '╒': 0xD5, '╓': 0xD6, '╔': 0xC9, '╕': 0xB8, '╖': 0xB7, '╗': 0xBB, '╘': 0xD4, '╙': 0xD3, '╚': 0xC8,
+	'╛': 0xBE, '╜': 0xBD, '╝': 0xBC, '╞': 0xC6, '╟': 0xC7, '╠': 0xCC, '╡': 0xB5, '╢': 0xB6, '╣': 0xB9, '╤': 0xD1, '╥': 0xD2,
+	'╦': 0xCB, '╧': 0xCF, '╨': 0xD0, '╩': 0xCA, '╪': 0xD8, '╫': 0xD7, '╬': 0xCE,
+}
+
+func cp437Parts() map[BoxParts]rune {
+	chars := map[BoxParts]rune{
+		BoxParts{BoxNone, BoxNone, BoxNone, BoxNone}: ' ',
+	}
+	for char := range cp437Codes {
+		chars[reverseUnicode(char)] = char
+	}
+	// Half lines as whole lines
+	for _, bt := range []BoxType{BoxSingle, BoxDouble} {
+		vertical, horizontal := chars[BoxParts{bt, bt, BoxNone, BoxNone}], chars[BoxParts{BoxNone, BoxNone, bt, bt}]
+		chars[BoxParts{bt, BoxNone, BoxNone, BoxNone}] = vertical
+		chars[BoxParts{BoxNone, bt, BoxNone, BoxNone}] = vertical
+		chars[BoxParts{BoxNone, BoxNone, bt, BoxNone}] = horizontal
+		chars[BoxParts{BoxNone, BoxNone, BoxNone, bt}] = horizontal
+	}
+	return substitute(chars, BoxSingle, BoxHeavy)
+}
+
+func encodeCp437(str string) string {
+	encoded := strings.Builder{}
+	for _, r := range str {
+		switch code, ok := cp437Codes[r]; {
+		case ok:
+			encoded.WriteByte(code)
+		case r < 0x80:
+			encoded.WriteByte(byte(r))
+		default:
+			encoded.WriteByte('?')
+		}
+	}
+	return encoded.String()
+}
+
+// DEC special graphics letters for box drawing characters
+var decLetters = map[rune]rune{
+	'─': 'q', '│': 'x', '┌': 'l', '┐': 'k', '└': 'm', '┘': 'j', '├': 't', '┤': 'u', '┬': 'w', '┴': 'v', '┼': 'n',
+}
+
+const (
+	// Designate G0 as DEC special graphics / US ASCII
+	decShiftIn  = "\u001b(0"
+	decShiftOut = "\u001b(B"
+)
+
+func decParts() map[BoxParts]rune {
+	chars := combinations([]BoxType{BoxNone, BoxSingle}, func(parts BoxParts) rune {
+		switch parts {
+		case BoxParts{BoxSingle, BoxNone, BoxNone, BoxNone}, BoxParts{BoxNone, BoxSingle, BoxNone, BoxNone}:
+			return '│'
+		case BoxParts{BoxNone, BoxNone, BoxSingle, BoxNone}, BoxParts{BoxNone, BoxNone, BoxNone, BoxSingle}:
+			return '─'
+		}
+		return boxParts[parts]
+	})
+	return substitute(chars, BoxSingle, BoxDouble, BoxHeavy)
+}
+
+func encodeDec(str string) string {
+	encoded := strings.Builder{}
+	shifted := false
+	for _, r := range str {
+		letter, box := decLetters[r]
+		if box != shifted {
+			shifted = box
+			if shifted {
+				encoded.WriteString(decShiftIn)
+			} else {
+				encoded.WriteString(decShiftOut)
+			}
+		}
+		if box {
+			encoded.WriteRune(letter)
+		} else {
+			encoded.WriteRune(r)
+		}
+	}
+	if shifted {
+		encoded.WriteString(decShiftOut)
+	}
+	return encoded.String()
+}
+
+// Add characters for missing types, using those of an existing type
+func substitute(chars map[BoxParts]rune, existing BoxType, missing ...BoxType) map[BoxParts]rune {
+	replace := func(bt BoxType) []BoxType {
+		if bt == existing {
+			return append([]BoxType{existing}, missing...)
+		}
+		return []BoxType{bt}
+	}
+	original := make(map[BoxParts]rune, len(chars))
+	for parts, char := range chars {
+		original[parts] = char
+	}
+	for parts, char := range original {
+		for _, up := range replace(parts.Up) {
+			for _, down := range replace(parts.Down) {
+				for _, left := range replace(parts.Left) {
+					for _, right := range replace(parts.Right) {
+						if _, ok := chars[BoxParts{up, down, left, right}]; !ok {
+							chars[BoxParts{up, down, left, right}] = char
+						}
+					}
+				}
+			}
+		}
+	}
+	return chars
+}
+
+// Parts for a unicode character (independent of the current set)
+func reverseUnicode(char rune) BoxParts {
+	for parts, c := range boxParts {
+		if c == char {
+			return parts
+		}
+	}
+	panic("Box drawing character not found: " + string(char))
+}
@@ -0,0 +1,43 @@
+package box_drawing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+func (bt BoxType) MarshalText() ([]byte, error) {
+	if _, err := ParseBoxType(bt.String()); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid box type: %d", int(bt)))
+	}
+	return []byte(bt.String()), nil
+}
+
+func (bt *BoxType) UnmarshalText(text []byte) error {
+	boxType, err := ParseBoxType(string(text))
+	if err != nil {
+		return err
+	}
+	*bt = boxType
+	return nil
+}
+
+func (bt BoxType) MarshalJSON() ([]byte, error) {
+	text, err := bt.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func (bt *BoxType) UnmarshalJSON(data []byte) error {
+	// null leaves the value unchanged
+	if string(data) == "null" {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return bt.UnmarshalText([]byte(str))
+}
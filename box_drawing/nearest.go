@@ -0,0 +1,67 @@
+package box_drawing
+
+// Get the closest available character (never fails)
+// Parts are degraded until a character is found, the degradation is the total cost of:
+// rounded/dashed to solid = 1 (heavy dashes to heavy then single = 2), heavy to single = 1,
+// double to single = 2 (heavy gives way first as double is more distinctive)
+// A degradation of 0 is an exact match
+// If no combination has a character (e.g. an unknown type) a space is returned and each part removed costs 3
+func GetBoxCharNearest(parts BoxParts) (char rune, degradation int) {
+	return characterSet.GetBoxCharNearest(parts)
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// Implementation
+// ----------------------------------------------------------------------------------------------------------------------------
+
+// Cost of removing a part (more than any degradation)
+const removedCost = 3
+
+type degradedBoxType struct {
+	BoxType
+	cost int
+}
+
+// Alternatives for a type, in order of preference
+func (bt BoxType) degradations() []degradedBoxType {
+	switch bt {
+	case BoxDouble:
+		return []degradedBoxType{{BoxDouble, 0}, {BoxSingle, 2}}
+	case BoxHeavy:
+		return []degradedBoxType{{BoxHeavy, 0}, {BoxSingle, 1}}
+	case BoxDoubleDashHeavy, BoxTripleDashHeavy, BoxQuadrupleDashHeavy:
+		return []degradedBoxType{{bt, 0}, {BoxHeavy, 1}, {BoxSingle, 2}}
+	case BoxRounded, BoxDoubleDash, BoxTripleDash, BoxQuadrupleDash:
+		return []degradedBoxType{{bt, 0}, {BoxSingle, 1}}
+	}
+	return []degradedBoxType{{bt, 0}}
+}
+
+// Find the lowest cost combination of degraded parts in the table
+func nearest(table map[BoxParts]rune, parts BoxParts) (char rune, degradation int) {
+	degradation = -1
+	for _, up := range parts.Up.degradations() {
+		for _, down := range parts.Down.degradations() {
+			for _, left := range parts.Left.degradations() {
+				for _, right := range parts.Right.degradations() {
+					cost := up.cost + down.cost + left.cost + right.cost
+					if degradation >= 0 && cost >= degradation {
+						continue
+					}
+					if found, ok := table[BoxParts{up.BoxType, down.BoxType, left.BoxType, right.BoxType}]; ok {
+						char, degradation = found, cost
+					}
+				}
+			}
+		}
+	}
+	if degradation < 0 {
+		char, degradation = ' ', 0
+		for _, part := range []BoxType{parts.Up, parts.Down, parts.Left, parts.Right} {
+			if part != BoxNone {
+				degradation += removedCost
+			}
+		}
+	}
+	return char, degradation
+}
@@ -0,0 +1,252 @@
+package canvas
+
+import (
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/box_drawing"
+)
+
+// Grid of terminal cells for drawing lines and text
+// The canvas grows to fit whatever is drawn, positions are (column,row) from (0,0) top left
+// Where lines meet, the junction character is calculated from all lines through the cell
+type Canvas struct {
+	rows [][]cell
+}
+
+func NewCanvas() *Canvas {
+	return &Canvas{}
+}
+
+// Width in cells
+func (c *Canvas) Width() int {
+	width := 0
+	for _, row := range c.rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Height in cells
+func (c *Canvas) Height() int {
+	return len(c.rows)
+}
+
+// Draw a line between the cells (inclusive)
+// The end cells only have the half of the line towards the other end so they join up with lines already drawn
+func (c *Canvas) DrawHorizontal(x1, x2, y int, boxType box_drawing.BoxType, attributes ansi.Attributes) {
+	if x2 < x1 {
+		x1, x2 = x2, x1
+	}
+	for x := x1; x <= x2; x++ {
+		parts := box_drawing.BoxParts{
+			Left:  box_drawing.ConditionalBoxType(x > x1, boxType, box_drawing.BoxNone),
+			Right: box_drawing.ConditionalBoxType(x < x2, boxType, box_drawing.BoxNone)}
+		c.mergeBox(x, y, parts, attributes)
+	}
+}
+
+// Draw a line between the cells (inclusive)
+// The end cells only have the half of the line towards the other end so they join up with lines already drawn
+func (c *Canvas) DrawVertical(x, y1, y2 int, boxType box_drawing.BoxType, attributes ansi.Attributes) {
+	if y2 < y1 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		parts := box_drawing.BoxParts{
+			Up:   box_drawing.ConditionalBoxType(y > y1, boxType, box_drawing.BoxNone),
+			Down: box_drawing.ConditionalBoxType(y < y2, boxType, box_drawing.BoxNone)}
+		c.mergeBox(x, y, parts, attributes)
+	}
+}
+
+// Draw a box with corners at the cells
+func (c *Canvas) DrawBox(x1, y1, x2, y2 int, boxType box_drawing.BoxType, attributes ansi.Attributes) {
+	c.DrawHorizontal(x1, x2, y1, boxType, attributes)
+	c.DrawHorizontal(x1, x2, y2, boxType, attributes)
+	c.DrawVertical(x1, y1, y2, boxType, attributes)
+	c.DrawVertical(x2, y1, y2, boxType, attributes)
+}
+
+// Add line parts to the cell, joining the lines already in the cell
+func (c *Canvas) DrawParts(x, y int, parts box_drawing.BoxParts, attributes ansi.Attributes) {
+	c.mergeBox(x, y, parts, attributes)
+}
+
+// Write text (may contain escape sequences) starting at the cell
+// Text is a single line, returns the width written
+func (c *Canvas) SetText(x, y int, text string) int {
+	return c.SetAttributedText(x, y, ansi.ParseAttributedText(text))
+}
+
+// Write text starting at the cell, returns the width written
+func (c *Canvas) SetAttributedText(x, y int, text ansi.AttributedText) int {
+	width := text.Width()
+	for pos := 0; pos < width; {
+		char, _ := text.CellAt(pos)
+		charWidth := ansi.StringWidth(char.String)
+		c.setText(x+pos, y, char, charWidth)
+		pos += charWidth
+	}
+	return width
+}
+
+// Lines with escape sequences, each line ends with no attributes
+// Lines are padded with spaces to the width of the canvas
+// Lines are drawn with the current character set (see box_drawing.Encode for output)
+func (c *Canvas) Lines() []string {
+	width := c.Width()
+	lines := make([]string, len(c.rows))
+	for y, row := range c.rows {
+		lines[y] = renderRow(row, width)
+	}
+	return lines
+}
+
+func (c *Canvas) String() string {
+	return strings.Join(c.Lines(), "\n")
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// Implementation
+// ----------------------------------------------------------------------------------------------------------------------------
+
+type cell struct {
+	// Text (empty for the continuation of a wide character)
+	text       string
+	attributes ansi.Attributes
+	link       string
+	// Line parts, the character is looked up when rendering
+	box   bool
+	parts box_drawing.BoxParts
+	// Cell is covered by the previous wide character
+	continuation bool
+}
+
+var emptyCell = cell{text: " ", attributes: ansi.NoAttributes}
+
+func (c *Canvas) mergeBox(x, y int, parts box_drawing.BoxParts, attributes ansi.Attributes) {
+	if !c.valid(x, y) {
+		return
+	}
+	existing := c.cellParts(x, y)
+	merged := box_drawing.BoxParts{
+		Up:    mergeBoxType(existing.Up, parts.Up),
+		Down:  mergeBoxType(existing.Down, parts.Down),
+		Left:  mergeBoxType(existing.Left, parts.Left),
+		Right: mergeBoxType(existing.Right, parts.Right),
+	}
+	c.set(x, y, cell{attributes: attributes, box: true, parts: merged}, 1)
+}
+
+// Parts of lines already in the cell (including box characters written as text)
+func (c *Canvas) cellParts(x, y int) (parts box_drawing.BoxParts) {
+	if y >= len(c.rows) || x >= len(c.rows[y]) {
+		return parts
+	}
+	existing := c.rows[y][x]
+	if existing.box {
+		return existing.parts
+	}
+	if runes := []rune(existing.text); len(runes) == 1 {
+		parts, _ = box_drawing.Lookup(runes[0])
+	}
+	return parts
+}
+
+// New line replaces the existing line
+func mergeBoxType(existing, new box_drawing.BoxType) box_drawing.BoxType {
+	return box_drawing.ConditionalBoxType(new != box_drawing.BoxNone, new, existing)
+}
+
+func (c *Canvas) setText(x, y int, char ansi.AttributeString, width int) {
+	if !c.valid(x, y) {
+		return
+	}
+	c.set(x, y, cell{text: char.String, attributes: char.Attributes, link: char.Link}, width)
+}
+
+func (c *Canvas) valid(x, y int) bool {
+	return x >= 0 && y >= 0
+}
+
+// Set the cell (and continuation cells for wide characters)
+// Wide characters partly overwritten are replaced with spaces
+func (c *Canvas) set(x, y int, newCell cell, width int) {
+	c.grow(x+width-1, y)
+	row := c.rows[y]
+	for i := 0; i < width; i++ {
+		c.clearWide(row, x+i)
+	}
+	row[x] = newCell
+	for i := 1; i < width; i++ {
+		row[x+i] = cell{attributes: newCell.attributes, link: newCell.link, continuation: true}
+	}
+}
+
+// Clear any wide character covering the cell
+func (c *Canvas) clearWide(row []cell, x int) {
+	start := x
+	for start > 0 && row[start].continuation {
+		start--
+	}
+	end := x + 1
+	for end < len(row) && row[end].continuation {
+		end++
+	}
+	if end-start > 1 {
+		for i := start; i < end; i++ {
+			row[i] = emptyCell
+		}
+	}
+}
+
+func (c *Canvas) grow(x, y int) {
+	for len(c.rows) <= y {
+		c.rows = append(c.rows, nil)
+	}
+	for len(c.rows[y]) <= x {
+		c.rows[y] = append(c.rows[y], emptyCell)
+	}
+}
+
+func renderRow(row []cell, width int) string {
+	text := strings.Builder{}
+	writer := ansi.NewWriter(&text)
+	set := box_drawing.GetCharacterSet()
+	link := ""
+	for x := 0; x < width; x++ {
+		current := emptyCell
+		if x < len(row) {
+			current = row[x]
+		}
+		if current.continuation {
+			continue
+		}
+		if current.link != link {
+			link = current.link
+			text.WriteString(ansi.HyperlinkCode(link))
+		}
+		writer.SetAttributes(current.attributes)
+		if current.box {
+			writer.WriteString(string(boxChar(set, current.parts)))
+		} else {
+			writer.WriteString(current.text)
+		}
+	}
+	if link != "" {
+		text.WriteString(ansi.HyperlinkCode(""))
+	}
+	writer.Close()
+	return text.String()
+}
+
+func boxChar(set *box_drawing.CharacterSet, parts box_drawing.BoxParts) rune {
+	if char, ok := set.GetBoxCharMixed(parts); ok {
+		return char
+	}
+	char, _ := set.GetBoxCharNearest(parts)
+	return char
+}
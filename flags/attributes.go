@@ -0,0 +1,43 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+)
+
+// flag.Value (and pflag.Value) for attributes e.g. "yellow on black bold"
+type AttributesValue ansi.Attributes
+
+// Create value with a default, storing into p
+func NewAttributesValue(val ansi.Attributes, p *ansi.Attributes) *AttributesValue {
+	*p = val
+	return (*AttributesValue)(p)
+}
+
+func (v *AttributesValue) Set(str string) error {
+	attributes, err := ansi.ParseAttributes(str)
+	if err != nil {
+		return err
+	}
+	*v = AttributesValue(attributes)
+	return nil
+}
+
+func (v *AttributesValue) String() string {
+	return ansi.Attributes(*v).String()
+}
+
+func (v *AttributesValue) Type() string {
+	return "attributes"
+}
+
+// Words (colours, "on" and styles) for shell completion
+func (v *AttributesValue) Values() []string {
+	values := colorNames()
+	values = append(values, "on")
+	for _, style := range ansi.StyleNames() {
+		values = append(values, strings.ToLower(style))
+	}
+	return values
+}
@@ -0,0 +1,42 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/atrico-go/console/box_drawing"
+)
+
+// flag.Value (and pflag.Value) for a box type
+type BoxTypeValue box_drawing.BoxType
+
+// Create value with a default, storing into p
+func NewBoxTypeValue(val box_drawing.BoxType, p *box_drawing.BoxType) *BoxTypeValue {
+	*p = val
+	return (*BoxTypeValue)(p)
+}
+
+func (v *BoxTypeValue) Set(str string) error {
+	bt, err := box_drawing.ParseBoxType(str)
+	if err != nil {
+		return err
+	}
+	*v = BoxTypeValue(bt)
+	return nil
+}
+
+func (v *BoxTypeValue) String() string {
+	return box_drawing.BoxType(*v).String()
+}
+
+func (v *BoxTypeValue) Type() string {
+	return "boxType"
+}
+
+// Valid values (for shell completion)
+func (v *BoxTypeValue) Values() []string {
+	values := make([]string, 0)
+	for _, bt := range box_drawing.BoxTypes() {
+		values = append(values, strings.ToLower(bt.String()))
+	}
+	return values
+}
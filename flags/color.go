@@ -0,0 +1,50 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/atrico-go/console/ansi/color"
+)
+
+// flag.Value (and pflag.Value) for a colour
+type ColorValue color.Color
+
+// Create value with a default, storing into p
+func NewColorValue(val color.Color, p *color.Color) *ColorValue {
+	*p = val
+	return (*ColorValue)(p)
+}
+
+func (v *ColorValue) Set(str string) error {
+	col, err := color.ParseColor(str)
+	if err != nil {
+		return err
+	}
+	*v = ColorValue(col)
+	return nil
+}
+
+func (v *ColorValue) String() string {
+	return color.Color(*v).String()
+}
+
+func (v *ColorValue) Type() string {
+	return "color"
+}
+
+// Named colours (for shell completion)
+func (v *ColorValue) Values() []string {
+	return colorNames()
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func colorNames() []string {
+	values := make([]string, 0)
+	for _, col := range append(color.StandardColors(), color.None) {
+		values = append(values, strings.ToLower(col.String()))
+	}
+	return values
+}
@@ -0,0 +1,112 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+)
+
+// When to use colour
+type ColorMode int
+
+const (
+	// Detect from the environment, no colour if the output is not a terminal
+	ColorAuto ColorMode = 0
+	// Always use colour
+	ColorAlways ColorMode = 1
+	// Never use colour
+	ColorNever ColorMode = 2
+)
+
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAuto:
+		return "auto"
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	}
+	return fmt.Sprintf("Unknown ColorMode (%d)", int(m))
+}
+
+// Colour profile for this mode writing to the output (e.g. os.Stdout)
+// always uses the detected profile, but at least 16 colours
+// auto uses the detected profile if the output is a terminal
+func (m ColorMode) Profile(output io.Writer) ansi.ColorProfile {
+	switch m {
+	case ColorNever:
+		return ansi.NoColor
+	case ColorAlways:
+		if profile := ansi.DetectColorProfile(); profile > ansi.Ansi16 {
+			return profile
+		}
+		return ansi.Ansi16
+	}
+	if !IsTerminal(output) {
+		return ansi.NoColor
+	}
+	return ansi.DetectColorProfile()
+}
+
+// Set the ansi colour profile for this mode writing to the output (e.g. os.Stdout)
+func (m ColorMode) Apply(output io.Writer) {
+	ansi.SetColorProfile(m.Profile(output))
+}
+
+// Test if output is a terminal (character device)
+// Replace to use a different test (e.g. golang.org/x/term)
+var IsTerminal = func(output io.Writer) bool {
+	file, ok := output.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// flag.Value (and pflag.Value) for --color=auto|always|never
+type ColorModeValue ColorMode
+
+// Create value with a default, storing into p
+func NewColorModeValue(val ColorMode, p *ColorMode) *ColorModeValue {
+	*p = val
+	return (*ColorModeValue)(p)
+}
+
+func (v *ColorModeValue) Set(str string) error {
+	for _, mode := range colorModes {
+		if strings.EqualFold(str, mode.String()) {
+			*v = ColorModeValue(mode)
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("invalid color mode: %s", str))
+}
+
+func (v *ColorModeValue) String() string {
+	return ColorMode(*v).String()
+}
+
+func (v *ColorModeValue) Type() string {
+	return "colorMode"
+}
+
+// Valid values (for shell completion)
+func (v *ColorModeValue) Values() []string {
+	values := make([]string, len(colorModes))
+	for i, mode := range colorModes {
+		values[i] = mode.String()
+	}
+	return values
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// internal
+// ----------------------------------------------------------------------------------------------------------------------------
+
+var colorModes = []ColorMode{ColorAuto, ColorAlways, ColorNever}
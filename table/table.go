@@ -0,0 +1,362 @@
+package table
+
+import (
+	"strings"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/box_drawing"
+	"github.com/atrico-go/console/canvas"
+)
+
+// Table of text (cells may contain escape sequences and newlines)
+// Lines are drawn through the box drawing character set so junctions (including spans) are joined up
+type Table struct {
+	Columns []Column
+	// Optional header row
+	Header *Row
+	Rows   []Row
+	// Type of border and separator lines (BoxNone for no lines, columns are separated by the padding)
+	Border           box_drawing.BoxType
+	BorderAttributes ansi.Attributes
+	// Header separator is heavy (see BoxType.HeavyIf)
+	HeavyHeader bool
+	// Separator line between rows
+	RowSeparators bool
+	// Spaces either side of the text in each cell (negative is treated as 0)
+	Padding int
+}
+
+// Column settings (columns without settings use the zero value)
+type Column struct {
+	Align ansi.Alignment
+	// Width of text (0 for no limit)
+	MinWidth int
+	MaxWidth int
+	// Wrap text wider than MaxWidth (otherwise it is truncated with an ellipsis)
+	Wrap bool
+	// Attributes for cells in the column (nil for none)
+	Attributes *ansi.Attributes
+}
+
+type Row struct {
+	Cells []Cell
+	// Attributes for cells in the row, layered over the column attributes (nil for none)
+	Attributes *ansi.Attributes
+}
+
+type Cell struct {
+	Text string
+	// Number of columns covered (0 is treated as 1)
+	// Spanned cells use the settings of their first column
+	Span int
+	// Attributes for this cell, layered over the row and column attributes (nil for none)
+	Attributes *ansi.Attributes
+}
+
+func NewTable(border box_drawing.BoxType) *Table {
+	return &Table{Border: border, BorderAttributes: ansi.NoAttributes, Padding: 1}
+}
+
+// Set header from text
+func (t *Table) SetHeader(cells ...string) {
+	row := newRow(cells)
+	t.Header = &row
+}
+
+// Add row from text
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, newRow(cells))
+}
+
+// Lines with escape sequences, each line ends with no attributes
+func (t *Table) Lines() []string {
+	return t.render().Lines()
+}
+
+func (t *Table) String() string {
+	return t.render().String()
+}
+
+// ----------------------------------------------------------------------------------------------------------------------------
+// Implementation
+// ----------------------------------------------------------------------------------------------------------------------------
+
+func newRow(cells []string) Row {
+	row := Row{Cells: make([]Cell, len(cells))}
+	for i, text := range cells {
+		row.Cells[i] = Cell{Text: text}
+	}
+	return row
+}
+
+// Cell positioned in the table
+type placedCell struct {
+	Cell
+	column int
+	span   int
+}
+
+// Row of cells covering every column (missing cells are empty)
+func (t *Table) placeCells(row Row, columns int) []placedCell {
+	cells := make([]placedCell, 0, columns)
+	column := 0
+	for _, cell := range row.Cells {
+		span := cell.Span
+		if span < 1 {
+			span = 1
+		}
+		cells = append(cells, placedCell{cell, column, span})
+		column += span
+	}
+	for ; column < columns; column++ {
+		cells = append(cells, placedCell{Cell{}, column, 1})
+	}
+	return cells
+}
+
+func (t *Table) allRows() []Row {
+	if t.Header != nil {
+		return append([]Row{*t.Header}, t.Rows...)
+	}
+	return t.Rows
+}
+
+func (t *Table) columnCount(rows []Row) int {
+	count := len(t.Columns)
+	for _, row := range rows {
+		columns := 0
+		for _, cell := range t.placeCells(row, 0) {
+			columns += cell.span
+		}
+		if columns > count {
+			count = columns
+		}
+	}
+	return count
+}
+
+func (t *Table) column(idx int) Column {
+	if idx < len(t.Columns) {
+		return t.Columns[idx]
+	}
+	return Column{}
+}
+
+// Width of the text in each column
+func (t *Table) columnWidths(rows [][]placedCell, columns int) []int {
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for _, cell := range row {
+			if width := textWidth(cell.Text); cell.span == 1 && width > widths[cell.column] {
+				widths[cell.column] = width
+			}
+		}
+	}
+	for i := range widths {
+		column := t.column(i)
+		if widths[i] < column.MinWidth {
+			widths[i] = column.MinWidth
+		}
+		if column.MaxWidth > 0 && widths[i] > column.MaxWidth {
+			widths[i] = column.MaxWidth
+		}
+	}
+	// Widen columns (within their maximum) to fit spanned cells
+	for _, row := range rows {
+		for _, cell := range row {
+			if cell.span > 1 {
+				t.widen(widths, cell.column, cell.span, textWidth(cell.Text)-t.spannedWidth(widths, cell.column, cell.span))
+			}
+		}
+	}
+	return widths
+}
+
+func (t *Table) widen(widths []int, first, span, extra int) {
+	for grown := true; extra > 0 && grown; {
+		grown = false
+		for i := first; i < first+span && extra > 0; i++ {
+			if max := t.column(i).MaxWidth; max == 0 || widths[i] < max {
+				widths[i]++
+				extra--
+				grown = true
+			}
+		}
+	}
+}
+
+// Width of text in a spanned cell (including the padding and lines between the columns)
+func (t *Table) spannedWidth(widths []int, first, span int) int {
+	width := (span - 1) * (2*t.padding() + t.lineWidth())
+	for i := first; i < first+span; i++ {
+		width += widths[i]
+	}
+	return width
+}
+
+func textWidth(text string) int {
+	width := 0
+	for _, line := range strings.Split(text, "\n") {
+		if lineWidth := ansi.StringWidth(line); lineWidth > width {
+			width = lineWidth
+		}
+	}
+	return width
+}
+
+// Text lines of a cell, no wider than width
+func (t *Table) cellLines(cell placedCell, width int) []string {
+	var lines []string
+	if t.column(cell.column).Wrap {
+		lines = ansi.Wrap(cell.Text, width, ansi.WrapOptions{HardBreak: true})
+	} else {
+		lines = strings.Split(cell.Text, "\n")
+	}
+	// Wrapping lets through characters wider than the line
+	for i, line := range lines {
+		if ansi.StringWidth(line) > width {
+			lines[i] = ansi.Truncate(line, width, ansi.Ellipsis)
+		}
+	}
+	return lines
+}
+
+// Column, then row, then cell attributes layered
+// Each layer sets its colours (unless None) and styles, so a cell can't remove a colour or style of its row or column
+func (t *Table) cellAttributes(row Row, cell placedCell) ansi.Attributes {
+	attributes := ansi.NoAttributes
+	for _, layer := range []*ansi.Attributes{t.column(cell.column).Attributes, row.Attributes, cell.Attributes} {
+		if layer != nil {
+			attributes = attributes.Modify(layer.SetThis())
+		}
+	}
+	return attributes
+}
+
+// Padding (at least 0)
+func (t *Table) padding() int {
+	if t.Padding < 0 {
+		return 0
+	}
+	return t.Padding
+}
+
+// Width of border and separator lines
+func (t *Table) lineWidth() int {
+	if t.Border == box_drawing.BoxNone {
+		return 0
+	}
+	return 1
+}
+
+func (t *Table) render() *canvas.Canvas {
+	cnv := canvas.NewCanvas()
+	rows := t.allRows()
+	if len(rows) == 0 {
+		return cnv
+	}
+	columns := t.columnCount(rows)
+	placed := make([][]placedCell, len(rows))
+	for i, row := range rows {
+		placed[i] = t.placeCells(row, columns)
+	}
+	widths := t.columnWidths(placed, columns)
+	lineWidth := t.lineWidth()
+	// Position of the line before each column (and after the last)
+	edges := make([]int, columns+1)
+	for i, width := range widths {
+		edges[i+1] = edges[i] + width + 2*t.padding() + lineWidth
+	}
+	// Horizontal lines (by line) and vertical lines on each line of text (by position)
+	horizontals := make(map[int]box_drawing.BoxType)
+	verticals := make(map[int]map[int]bool, columns+1)
+	if lineWidth > 0 {
+		horizontals[0] = t.Border
+	}
+	y := lineWidth
+	for r, row := range placed {
+		lines := make([][]string, len(row))
+		height := 1
+		for i, cell := range row {
+			lines[i] = t.cellLines(cell, t.spannedWidth(widths, cell.column, cell.span))
+			if len(lines[i]) > height {
+				height = len(lines[i])
+			}
+		}
+		for i, cell := range row {
+			t.drawCell(cnv, edges[cell.column]+lineWidth, y, edges[cell.column+cell.span]-edges[cell.column]-lineWidth, height, cell, lines[i], t.cellAttributes(rows[r], cell))
+			// No line within spanned cells
+			for line := y; line < y+height && lineWidth > 0; line++ {
+				markVertical(verticals, edges[cell.column], line)
+				markVertical(verticals, edges[cell.column+cell.span], line)
+			}
+		}
+		y += height
+		switch {
+		case lineWidth == 0:
+			continue
+		case r == 0 && t.Header != nil && len(rows) > 1:
+			horizontals[y] = t.Border.HeavyIf(t.HeavyHeader)
+		case r == len(rows)-1 || t.RowSeparators:
+			horizontals[y] = t.Border
+		default:
+			continue
+		}
+		y++
+	}
+	for line, boxType := range horizontals {
+		cnv.DrawHorizontal(0, edges[columns], line, boxType, t.BorderAttributes)
+	}
+	for x, lines := range verticals {
+		t.drawVertical(cnv, x, lines, horizontals, y)
+	}
+	return cnv
+}
+
+func markVertical(verticals map[int]map[int]bool, x, y int) {
+	if verticals[x] == nil {
+		verticals[x] = make(map[int]bool)
+	}
+	verticals[x][y] = true
+}
+
+// Vertical line through the lines of text marked, joining the horizontal lines either side
+// Ends on lines of text (rather than horizontal lines) are full height so the line stops at the edge of the row
+func (t *Table) drawVertical(cnv *canvas.Canvas, x int, lines map[int]bool, horizontals map[int]box_drawing.BoxType, height int) {
+	start := -1
+	for y := 0; y <= height; y++ {
+		present := lines[y]
+		if _, ok := horizontals[y]; ok {
+			present = lines[y-1] || lines[y+1]
+		}
+		switch {
+		case present && start < 0:
+			start = y
+		case !present && start >= 0:
+			t.drawVerticalSegment(cnv, x, start, y-1, horizontals)
+			start = -1
+		}
+	}
+}
+
+func (t *Table) drawVerticalSegment(cnv *canvas.Canvas, x, start, end int, horizontals map[int]box_drawing.BoxType) {
+	cnv.DrawVertical(x, start, end, t.Border, t.BorderAttributes)
+	if _, ok := horizontals[start]; !ok {
+		cnv.DrawParts(x, start, box_drawing.BoxParts{Up: t.Border}, t.BorderAttributes)
+	}
+	if _, ok := horizontals[end]; !ok {
+		cnv.DrawParts(x, end, box_drawing.BoxParts{Down: t.Border}, t.BorderAttributes)
+	}
+}
+
+// Fill the cell (including padding) and write the text lines
+func (t *Table) drawCell(cnv *canvas.Canvas, x, y, width, height int, cell placedCell, lines []string, attributes ansi.Attributes) {
+	blank := attributes.SetThis().ApplyTo(strings.Repeat(" ", width))
+	align := t.column(cell.column).Align
+	for line := 0; line < height; line++ {
+		cnv.SetText(x, y+line, blank)
+		if line < len(lines) {
+			cnv.SetText(x+t.padding(), y+line, ansi.Pad(attributes.SetThis().ApplyTo(lines[line]), width-2*t.padding(), align, attributes))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+var redText = ansi.Attributes{Foreground: color.Red, Background: color.None}
+var blueText = ansi.Attributes{Foreground: color.Blue, Background: color.None, Underline: true}
+
+func Test_AttributedText_RoundTrip(t *testing.T) {
+	// Arrange
+	str := redText.SetThis().ApplyTo("red") + redText.CreateDeltaTo(blueText).ApplyTo("blue") + blueText.ResetThis().ApplyTo("plain")
+
+	// Act
+	text := ansi.ParseAttributedText(str)
+
+	// Assert
+	assert.Assert(t).That(text.Width(), is.EqualTo(12), "Correct width")
+	assert.Assert(t).That(text.Parts(), is.DeepEqualTo(ansi.ParseString(str)), "Correct parts")
+	assert.Assert(t).That(ansi.ParseString(text.String()), is.DeepEqualTo(ansi.ParseString(str)), "Round trip")
+	assert.Assert(t).That(text.String(), is.EqualTo(str), "Minimal codes")
+}
+
+func Test_AttributedText_CellAt(t *testing.T) {
+	// Arrange
+	text := ansi.NewAttributedText(ansi.AttributeString{String: "a中", Attributes: redText}, ansi.AttributeString{String: "b", Attributes: blueText})
+
+	// Act
+	char1, ok1 := text.CellAt(2)
+	char2, ok2 := text.CellAt(3)
+	_, ok3 := text.CellAt(4)
+
+	// Assert
+	assert.Assert(t).That(ok1, is.True, "1: Found")
+	assert.Assert(t).That(char1, is.EqualTo(ansi.AttributeString{String: "中", Attributes: redText}), "1: Second cell of wide char")
+	assert.Assert(t).That(ok2, is.True, "2: Found")
+	assert.Assert(t).That(char2, is.EqualTo(ansi.AttributeString{String: "b", Attributes: blueText}), "2: Correct char")
+	assert.Assert(t).That(ok3, is.False, "3: Not found")
+}
+
+func Test_AttributedText_Slice(t *testing.T) {
+	// Arrange
+	text := ansi.NewAttributedText(ansi.AttributeString{String: "abc中", Attributes: redText}, ansi.AttributeString{String: "def", Attributes: blueText})
+
+	// Act
+	slice1 := text.Slice(1, 5)
+	slice2 := text.Slice(4, 7)
+
+	// Assert
+	assert.Assert(t).That(slice1.Parts(), is.DeepEqualTo([]ansi.AttributeString{{String: "bc中", Attributes: redText}}), "Wide char included")
+	assert.Assert(t).That(slice2.Parts(), is.DeepEqualTo([]ansi.AttributeString{{String: "de", Attributes: blueText}}), "Partial wide char excluded")
+}
+
+func Test_AttributedText_AppendInsertReplace(t *testing.T) {
+	// Arrange
+	text := ansi.NewAttributedText(ansi.AttributeString{String: "hello", Attributes: redText})
+	other := ansi.NewAttributedText(ansi.AttributeString{String: "XY", Attributes: blueText})
+
+	// Act
+	appended := text.Append(other)
+	inserted := text.Insert(2, other)
+	replaced := text.Replace(1, 4, other)
+
+	// Assert
+	assert.Assert(t).That(appended.Parts(), is.DeepEqualTo([]ansi.AttributeString{{String: "hello", Attributes: redText}, {String: "XY", Attributes: blueText}}), "Appended")
+	assert.Assert(t).That(inserted.Parts(), is.DeepEqualTo([]ansi.AttributeString{{String: "he", Attributes: redText}, {String: "XY", Attributes: blueText}, {String: "llo", Attributes: redText}}), "Inserted")
+	assert.Assert(t).That(replaced.Parts(), is.DeepEqualTo([]ansi.AttributeString{{String: "h", Attributes: redText}, {String: "XY", Attributes: blueText}, {String: "o", Attributes: redText}}), "Replaced")
+	assert.Assert(t).That(text.Width(), is.EqualTo(5), "Original unchanged")
+}
@@ -0,0 +1,154 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/box_drawing"
+)
+
+func Test_BoxDrawingCharSet_DefaultIsUnicode(t *testing.T) {
+	// Act
+	set := box_drawing.GetCharacterSet()
+
+	// Assert
+	Assert(t).That(set, is.EqualTo(box_drawing.UnicodeSet), "Unicode")
+	Assert(t).That(set.String(), is.EqualTo("Unicode"), "Name")
+}
+
+func Test_BoxDrawingCharSet_SwitchSet(t *testing.T) {
+	// Arrange
+	defer box_drawing.SetCharacterSet(box_drawing.GetCharacterSet())
+	box_drawing.SetCharacterSet(box_drawing.AsciiSet)
+
+	// Act
+	char := box_drawing.MustGetBoxChar(true, true, true, true, box_drawing.BoxSingle)
+	horizontal := box_drawing.GetHorizontal(box_drawing.BoxDouble)
+	vertical := box_drawing.GetVertical(box_drawing.BoxHeavy)
+	parts, ok := box_drawing.Lookup('+')
+
+	// Assert
+	Assert(t).That(char, is.EqualTo('+'), "Cross")
+	Assert(t).That(horizontal, is.EqualTo('='), "Horizontal")
+	Assert(t).That(vertical, is.EqualTo('|'), "Vertical")
+	Assert(t).That(ok, is.True, "Lookup ok")
+	Assert(t).That(parts, is.EqualTo(box_drawing.BoxParts{Up: bS, Down: bS, Left: bS, Right: bS}), "Lookup parts")
+}
+
+func Test_BoxDrawingCharSet_Chars(t *testing.T) {
+	for _, tc := range charSetTestCases {
+		t.Run(fmt.Sprintf("%v %v", tc.set, tc.parts), func(t *testing.T) {
+			// Act
+			char, ok := tc.set.GetBoxCharMixed(tc.parts)
+
+			// Assert
+			Assert(t).That(ok, is.True, "Found")
+			Assert(t).That(char, is.EqualTo(tc.char), "Correct char")
+		})
+	}
+}
+
+func Test_BoxDrawingCharSet_Nearest(t *testing.T) {
+	// Act
+	char, degradation := box_drawing.Cp437Set.GetBoxCharNearest(box_drawing.BoxParts{Up: bD, Down: bS, Left: bN, Right: bS})
+
+	// Assert
+	Assert(t).That(char, is.EqualTo('├'), "Correct char")
+	Assert(t).That(degradation, is.EqualTo(2), "Correct degradation")
+}
+
+func Test_BoxDrawingCharSet_Lookup(t *testing.T) {
+	for _, set := range []*box_drawing.CharacterSet{box_drawing.AsciiSet, box_drawing.Cp437Set, box_drawing.DecSet} {
+		t.Run(set.String(), func(t *testing.T) {
+			for _, bt := range []box_drawing.BoxType{bS, bD, bH} {
+				parts := box_drawing.BoxParts{Up: bt, Down: bt, Left: bN, Right: bN}
+				// Act
+				char, _ := set.GetBoxCharNearest(parts)
+				lookup, ok := set.Lookup(char)
+				// Assert
+				Assert(t).That(ok, is.True, "Lookup ok")
+				found, _ := set.GetBoxCharMixed(lookup)
+				Assert(t).That(found, is.EqualTo(char), "Round trip")
+			}
+		})
+	}
+}
+
+func Test_BoxDrawingCharSet_Encode(t *testing.T) {
+	// Arrange
+	str := "\u001b[31m┌─┐ab…\u001b[0m"
+
+	// Act
+	unicode := box_drawing.UnicodeSet.Encode(str)
+	dec := box_drawing.DecSet.Encode(str)
+	cp437 := box_drawing.Cp437Set.Encode(str)
+
+	// Assert
+	Assert(t).That(unicode, is.EqualTo(str), "Unicode unchanged")
+	Assert(t).That(dec, is.EqualTo("\u001b[31m\u001b(0lqk\u001b(Bab…\u001b[0m"), "Dec shifted")
+	Assert(t).That(cp437, is.EqualTo("\u001b[31m\xda\xc4\xbfab?\u001b[0m"), "Cp437 bytes")
+}
+
+func Test_BoxDrawingCharSet_EncodeCurrentSet(t *testing.T) {
+	// Arrange
+	defer box_drawing.SetCharacterSet(box_drawing.GetCharacterSet())
+	box_drawing.SetCharacterSet(box_drawing.DecSet)
+
+	// Act
+	encoded := box_drawing.Encode(string(box_drawing.GetHorizontal(box_drawing.BoxDouble)))
+
+	// Assert
+	Assert(t).That(encoded, is.EqualTo("\u001b(0q\u001b(B"), "Encoded with current set")
+}
+
+func Test_BoxDrawingCharSet_Custom(t *testing.T) {
+	// Arrange
+	set := box_drawing.NewCharacterSet("Custom", map[box_drawing.BoxParts]rune{
+		{Up: bS, Down: bS, Left: bN, Right: bN}: '!',
+		{Up: bN, Down: bN, Left: bS, Right: bS}: '~',
+	})
+
+	// Act
+	vertical, ok := set.GetBoxCharMixed(box_drawing.BoxParts{Up: bS, Down: bS, Left: bN, Right: bN})
+	horizontal, degradation := set.GetBoxCharNearest(box_drawing.BoxParts{Up: bN, Down: bN, Left: bH, Right: bH})
+	_, okCross := set.GetBoxCharMixed(box_drawing.BoxParts{Up: bS, Down: bS, Left: bS, Right: bS})
+
+	// Assert
+	Assert(t).That(ok, is.True, "Vertical found")
+	Assert(t).That(vertical, is.EqualTo('!'), "Vertical")
+	Assert(t).That(horizontal, is.EqualTo('~'), "Horizontal")
+	Assert(t).That(degradation, is.EqualTo(2), "Degradation")
+	Assert(t).That(okCross, is.False, "Cross not found")
+}
+
+type charSetTestCase struct {
+	set   *box_drawing.CharacterSet
+	parts box_drawing.BoxParts
+	char  rune
+}
+
+var charSetTestCases = []charSetTestCase{
+	{box_drawing.UnicodeSet, box_drawing.BoxParts{Up: bS, Down: bS, Left: bS, Right: bS}, '┼'},
+	{box_drawing.UnicodeSet, box_drawing.BoxParts{Up: bN, Down: bR, Left: bN, Right: bR}, '╭'},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bN, Left: bN, Right: bN}, ' '},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bS, Left: bN, Right: bS}, '+'},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bR, Left: bN, Right: bR}, '+'},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bN, Left: bH, Right: bH}, '-'},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bN, Left: bD, Right: bD}, '='},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bN, Down: bN, Left: bQ, Right: bQ}, '-'},
+	{box_drawing.AsciiSet, box_drawing.BoxParts{Up: bD, Down: bD, Left: bN, Right: bN}, '|'},
+	{box_drawing.Cp437Set, box_drawing.BoxParts{Up: bN, Down: bS, Left: bN, Right: bS}, '┌'},
+	{box_drawing.Cp437Set, box_drawing.BoxParts{Up: bD, Down: bD, Left: bS, Right: bS}, '╫'},
+	{box_drawing.Cp437Set, box_drawing.BoxParts{Up: bN, Down: bR, Left: bN, Right: bR}, '┌'},
+	{box_drawing.Cp437Set, box_drawing.BoxParts{Up: bS, Down: bN, Left: bN, Right: bN}, '│'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bN, Down: bN, Left: bS, Right: bS}, '─'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bS, Down: bS, Left: bN, Right: bN}, '│'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bN, Down: bS, Left: bN, Right: bS}, '┌'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bS, Down: bS, Left: bS, Right: bS}, '┼'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bS, Down: bN, Left: bS, Right: bN}, '┘'},
+	{box_drawing.DecSet, box_drawing.BoxParts{Up: bD, Down: bH, Left: bH, Right: bD}, '┼'},
+	{box_drawing.Cp437Set, box_drawing.BoxParts{Up: bD, Down: bD, Left: bH, Right: bH}, '╫'},
+}
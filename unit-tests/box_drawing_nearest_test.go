@@ -0,0 +1,57 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/box_drawing"
+)
+
+func Test_BoxDrawingNearest(t *testing.T) {
+	for _, tc := range nearestTestCases {
+		t.Run(fmt.Sprintf("%v", tc.parts), func(t *testing.T) {
+			// Act
+			char, degradation := box_drawing.GetBoxCharNearest(tc.parts)
+			// Assert
+			Assert(t).That(char, is.EqualTo(tc.char), "Correct char")
+			Assert(t).That(degradation, is.EqualTo(tc.degradation), "Correct degradation")
+		})
+	}
+}
+
+type nearestTestCase struct {
+	parts       box_drawing.BoxParts
+	char        rune
+	degradation int
+}
+
+const (
+	bN = box_drawing.BoxNone
+	bS = box_drawing.BoxSingle
+	bD = box_drawing.BoxDouble
+	bH = box_drawing.BoxHeavy
+	bR = box_drawing.BoxRounded
+	bQ = box_drawing.BoxQuadrupleDashHeavy
+)
+
+var nearestTestCases = []nearestTestCase{
+	// Exact
+	{box_drawing.BoxParts{Up: bS, Down: bS, Left: bS, Right: bS}, '┼', 0},
+	{box_drawing.BoxParts{Up: bD, Down: bD, Left: bS, Right: bS}, '╫', 0},
+	{box_drawing.BoxParts{Up: bN, Down: bR, Left: bN, Right: bR}, '╭', 0},
+	// Double meets heavy
+	{box_drawing.BoxParts{Up: bD, Down: bD, Left: bH, Right: bH}, '╫', 2},
+	{box_drawing.BoxParts{Up: bN, Down: bH, Left: bD, Right: bN}, '╕', 1},
+	// Double on three sides
+	{box_drawing.BoxParts{Up: bD, Down: bD, Left: bD, Right: bS}, '╫', 2},
+	// Dashed and rounded
+	{box_drawing.BoxParts{Up: bQ, Down: bQ, Left: bH, Right: bN}, '┫', 2},
+	{box_drawing.BoxParts{Up: bR, Down: bR, Left: bN, Right: bR}, '├', 3},
+	{box_drawing.BoxParts{Up: bD, Down: bN, Left: bN, Right: bQ}, '╙', 2},
+	// No character
+	{box_drawing.BoxParts{Up: box_drawing.BoxType(99), Down: bN, Left: bS, Right: bN}, ' ', 6},
+	{box_drawing.BoxParts{Up: bN, Down: bN, Left: bN, Right: bN}, ' ', 0},
+}
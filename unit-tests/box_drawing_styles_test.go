@@ -0,0 +1,88 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/box_drawing"
+)
+
+func Test_BoxDrawingStyles_Chars(t *testing.T) {
+	for _, tc := range styleTestCases {
+		t.Run(fmt.Sprintf("%v", tc.BoxType), func(t *testing.T) {
+			// Act
+			horizontal := box_drawing.GetHorizontal(tc.BoxType)
+			vertical := box_drawing.GetVertical(tc.BoxType)
+			topLeft := box_drawing.MustGetBoxChar(false, true, false, true, tc.BoxType)
+			topRight := box_drawing.MustGetBoxChar(false, true, true, false, tc.BoxType)
+			bottomLeft := box_drawing.MustGetBoxChar(true, false, false, true, tc.BoxType)
+			bottomRight := box_drawing.MustGetBoxChar(true, false, true, false, tc.BoxType)
+			cross := box_drawing.MustGetBoxChar(true, true, true, true, tc.BoxType)
+			// Assert
+			Assert(t).That(horizontal, is.EqualTo(tc.horizontal), "Horizontal")
+			Assert(t).That(vertical, is.EqualTo(tc.vertical), "Vertical")
+			Assert(t).That(topLeft, is.EqualTo(tc.topLeft), "Top left")
+			Assert(t).That(topRight, is.EqualTo(tc.topRight), "Top right")
+			Assert(t).That(bottomLeft, is.EqualTo(tc.bottomLeft), "Bottom left")
+			Assert(t).That(bottomRight, is.EqualTo(tc.bottomRight), "Bottom right")
+			Assert(t).That(cross, is.EqualTo(tc.cross), "Cross (fallback)")
+		})
+	}
+}
+
+func Test_BoxDrawingStyles_Lookup(t *testing.T) {
+	// Act
+	rounded, okRounded := box_drawing.Lookup('╭')
+	dashed, okDashed := box_drawing.Lookup('┇')
+
+	// Assert
+	Assert(t).That(okRounded, is.True, "Rounded found")
+	Assert(t).That(rounded, is.EqualTo(box_drawing.BoxParts{Up: box_drawing.BoxNone, Down: box_drawing.BoxRounded, Left: box_drawing.BoxNone, Right: box_drawing.BoxRounded}), "Rounded parts")
+	Assert(t).That(okDashed, is.True, "Dashed found")
+	Assert(t).That(dashed, is.EqualTo(box_drawing.BoxParts{Up: box_drawing.BoxTripleDashHeavy, Down: box_drawing.BoxTripleDashHeavy, Left: box_drawing.BoxNone, Right: box_drawing.BoxNone}), "Dashed parts")
+}
+
+func Test_BoxDrawingStyles_Parse(t *testing.T) {
+	for _, bt := range box_drawing.BoxTypes() {
+		t.Run(bt.String(), func(t *testing.T) {
+			// Act
+			parsed, err := box_drawing.ParseBoxType(bt.String())
+			// Assert
+			Assert(t).That(err, is.Nil, "No error")
+			Assert(t).That(parsed, is.EqualTo(bt), "Correct type")
+		})
+	}
+}
+
+func Test_BoxDrawingStyles_ParseSeparators(t *testing.T) {
+	// Act
+	parsed, err := box_drawing.ParseBoxType("box-triple_dash")
+
+	// Assert
+	Assert(t).That(err, is.Nil, "No error")
+	Assert(t).That(parsed, is.EqualTo(box_drawing.BoxTripleDash), "Separators ignored")
+}
+
+type styleTestCase struct {
+	box_drawing.BoxType
+	horizontal  rune
+	vertical    rune
+	topLeft     rune
+	topRight    rune
+	bottomLeft  rune
+	bottomRight rune
+	cross       rune
+}
+
+var styleTestCases = []styleTestCase{
+	{box_drawing.BoxRounded, '─', '│', '╭', '╮', '╰', '╯', '┼'},
+	{box_drawing.BoxDoubleDash, '╌', '╎', '┌', '┐', '└', '┘', '┼'},
+	{box_drawing.BoxDoubleDashHeavy, '╍', '╏', '┏', '┓', '┗', '┛', '╋'},
+	{box_drawing.BoxTripleDash, '┄', '┆', '┌', '┐', '└', '┘', '┼'},
+	{box_drawing.BoxTripleDashHeavy, '┅', '┇', '┏', '┓', '┗', '┛', '╋'},
+	{box_drawing.BoxQuadrupleDash, '┈', '┊', '┌', '┐', '└', '┘', '┼'},
+	{box_drawing.BoxQuadrupleDashHeavy, '┉', '┋', '┏', '┓', '┗', '┛', '╋'},
+}
@@ -0,0 +1,140 @@
+package unit_tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+	"github.com/atrico-go/console/box_drawing"
+	"github.com/atrico-go/console/canvas"
+)
+
+func Test_Canvas_Box(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+
+	// Act
+	cnv.DrawBox(0, 0, 3, 2, box_drawing.BoxSingle, ansi.NoAttributes)
+
+	// Assert
+	expected := []string{
+		"┌──┐",
+		"│  │",
+		"└──┘",
+	}
+	assert.Assert(t).That(cnv.Width(), is.EqualTo(4), "Width")
+	assert.Assert(t).That(cnv.Height(), is.EqualTo(3), "Height")
+	assert.Assert(t).That(cnv.Lines(), is.DeepEqualTo(expected), "Correct lines")
+}
+
+func Test_Canvas_Junctions(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+
+	// Act
+	cnv.DrawBox(0, 0, 4, 4, box_drawing.BoxDouble, ansi.NoAttributes)
+	cnv.DrawHorizontal(0, 4, 2, box_drawing.BoxSingle, ansi.NoAttributes)
+	cnv.DrawVertical(2, 0, 4, box_drawing.BoxHeavy, ansi.NoAttributes)
+
+	// Assert
+	expected := []string{
+		"╔═╤═╗",
+		"║ ┃ ║",
+		"╟─╂─╢",
+		"║ ┃ ║",
+		"╚═╧═╝",
+	}
+	assert.Assert(t).That(cnv.String(), is.EqualTo(strings.Join(expected, "\n")), "Correct junctions")
+}
+
+func Test_Canvas_MergeWithText(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+	cnv.SetText(0, 0, "a─b")
+
+	// Act
+	cnv.DrawVertical(1, 0, 1, box_drawing.BoxSingle, ansi.NoAttributes)
+
+	// Assert
+	expected := []string{
+		"a┬b",
+		" ╵ ",
+	}
+	assert.Assert(t).That(cnv.Lines(), is.DeepEqualTo(expected), "Merged with box character in text")
+}
+
+func Test_Canvas_CharacterSet(t *testing.T) {
+	// Arrange
+	defer box_drawing.SetCharacterSet(box_drawing.GetCharacterSet())
+	cnv := canvas.NewCanvas()
+	cnv.DrawBox(0, 0, 2, 1, box_drawing.BoxSingle, ansi.NoAttributes)
+	cnv.DrawVertical(1, 0, 1, box_drawing.BoxSingle, ansi.NoAttributes)
+
+	// Act
+	box_drawing.SetCharacterSet(box_drawing.AsciiSet)
+	ascii := cnv.Lines()
+	box_drawing.SetCharacterSet(box_drawing.DecSet)
+	dec := box_drawing.Encode(cnv.String())
+
+	// Assert
+	assert.Assert(t).That(ascii, is.DeepEqualTo([]string{"+++", "+++"}), "Ascii")
+	assert.Assert(t).That(dec, is.EqualTo("\u001b(0lwk\u001b(B\n\u001b(0mvj\u001b(B"), "Dec")
+}
+
+func Test_Canvas_Attributes(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+	red := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	blue := ansi.Attributes{Foreground: color.Blue, Background: color.None}
+
+	// Act
+	cnv.DrawHorizontal(0, 3, 0, box_drawing.BoxSingle, red)
+	cnv.SetText(1, 0, blue.SetThis().ApplyTo("ab"))
+	parsed := ansi.ParseString(cnv.Lines()[0])
+
+	// Assert
+	expected := []ansi.AttributeString{
+		{String: "╶", Attributes: red},
+		{String: "ab", Attributes: blue},
+		{String: "╴", Attributes: red},
+	}
+	assert.Assert(t).That(parsed, is.DeepEqualTo(expected), "Correct parts")
+	assert.Assert(t).That(ansi.ParseString(cnv.Lines()[0] + "x")[3].Attributes, is.EqualTo(ansi.NoAttributes), "Ends with no attributes")
+}
+
+func Test_Canvas_WideCharacters(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+	width := cnv.SetText(0, 0, "中文x")
+
+	// Act
+	cnv.DrawVertical(1, 0, 1, box_drawing.BoxSingle, ansi.NoAttributes)
+
+	// Assert
+	expected := []string{
+		" ╷文x",
+		" ╵   ",
+	}
+	assert.Assert(t).That(width, is.EqualTo(5), "Width written")
+	assert.Assert(t).That(cnv.Lines(), is.DeepEqualTo(expected), "Wide character cleared")
+}
+
+func Test_Canvas_Link(t *testing.T) {
+	// Arrange
+	cnv := canvas.NewCanvas()
+
+	// Act
+	cnv.SetText(0, 0, "a"+ansi.Hyperlink("http://example.com", "b"))
+	parsed := ansi.ParseString(cnv.String())
+
+	// Assert
+	expected := []ansi.AttributeString{
+		{String: "a", Attributes: ansi.NoAttributes},
+		{String: "b", Attributes: ansi.NoAttributes, Link: "http://example.com"},
+	}
+	assert.Assert(t).That(parsed, is.DeepEqualTo(expected), "Correct parts")
+}
@@ -0,0 +1,52 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Escape_Default7Bit(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: color.Red, Background: color.None}
+
+	// Act
+	code := attribs.SetThis().GetCodeString()
+
+	// Assert
+	assert.Assert(t).That(code, is.EqualTo("\x1b[31m"), "7 bit CSI")
+}
+
+func Test_Escape_8Bit(t *testing.T) {
+	// Arrange
+	defer ansi.SetEscapeForm(ansi.GetEscapeForm())
+	ansi.SetEscapeForm(ansi.Escape8Bit)
+	attribs := ansi.Attributes{Foreground: color.Red, Background: color.None}
+
+	// Act
+	code := attribs.SetThis().GetCodeString()
+
+	// Assert
+	assert.Assert(t).That(code, is.EqualTo("\u009b31m"), "8 bit CSI")
+}
+
+func Test_Escape_ParseBothForms(t *testing.T) {
+	// Arrange
+	str := "\x1b[01;34mdir\x1b[0m \u009b32mfile\u009b0m"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(3), "3 entries")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo("dir"), "1: Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.Blue, Background: color.None, Bold: true}), "1: Correct attributes")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo(" "), "2: Correct string")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(ansi.NoAttributes), "2: No attributes")
+	assert.Assert(t).That(parsed[2].String, is.EqualTo("file"), "3: Correct string")
+	assert.Assert(t).That(parsed[2].Attributes.Foreground, is.EqualTo(color.Green), "3: Correct foreground")
+}
@@ -0,0 +1,153 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_ExtendedColors_Indexed(t *testing.T) {
+	// Arrange
+	index := uint8(randomValues.IntBetween(0, 256))
+
+	// Act
+	col := color.Indexed(index)
+	fmt.Println(col)
+
+	// Assert
+	assert.Assert(t).That(col.IsIndexed(), is.True, "Is indexed")
+	assert.Assert(t).That(col.Index(), is.EqualTo(index), "Correct index")
+	assert.Assert(t).That(color.Red.IsIndexed(), is.False, "Standard colour not indexed")
+}
+
+func Test_ExtendedColors_IndexedCodes(t *testing.T) {
+	// Arrange
+	target := ansi.Attributes{Foreground: color.Indexed(208), Background: color.Indexed(17)}
+
+	// Act
+	delta := target.SetThis()
+
+	// Assert
+	assert.Assert(t).That(delta.GetCodes(), is.DeepEqualTo([]int{38, 5, 208, 48, 5, 17}), "Correct codes")
+}
+
+func Test_ExtendedColors_IndexedModify(t *testing.T) {
+	// Arrange
+	original := ansi.Attributes{Foreground: randomColour(), Background: randomColour()}
+	target := ansi.Attributes{Foreground: randomIndexedColour(), Background: randomIndexedColour()}
+	fmt.Printf("%s => %s\n", original, target)
+
+	// Act
+	delta := original.CreateDeltaTo(target)
+	newAttributes := original.Modify(delta)
+	fmt.Printf("= %s\n", newAttributes)
+
+	// Assert
+	assert.Assert(t).That(newAttributes, is.EqualTo(target), "Correct attributes")
+}
+
+func Test_ExtendedColors_IndexedParse(t *testing.T) {
+	// Arrange
+	target := ansi.Attributes{Foreground: randomIndexedColour(), Background: randomIndexedColour()}
+	raw := randomValues.String()
+	str := target.SetThis().ApplyTo(raw)
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(1), "One entry")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo(raw), "Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(target), "Correct attributes")
+}
+
+func randomIndexedColour() color.Color {
+	return color.Indexed(uint8(randomValues.IntBetween(0, 256)))
+}
+
+func Test_ExtendedColors_RGB(t *testing.T) {
+	// Arrange
+	r, g, b := uint8(randomValues.IntBetween(0, 256)), uint8(randomValues.IntBetween(0, 256)), uint8(randomValues.IntBetween(0, 256))
+
+	// Act
+	col := color.RGB(r, g, b)
+	fmt.Println(col)
+	r2, g2, b2 := col.RGB()
+
+	// Assert
+	assert.Assert(t).That(col.IsRGB(), is.True, "Is RGB")
+	assert.Assert(t).That(col.IsIndexed(), is.False, "Not indexed")
+	assert.Assert(t).That(r2, is.EqualTo(r), "Correct red")
+	assert.Assert(t).That(g2, is.EqualTo(g), "Correct green")
+	assert.Assert(t).That(b2, is.EqualTo(b), "Correct blue")
+}
+
+func Test_ExtendedColors_RGBCodes(t *testing.T) {
+	// Arrange
+	target := ansi.Attributes{Foreground: color.RGB(255, 128, 0), Background: color.RGB(0, 0, 64)}
+
+	// Act
+	delta := target.SetThis()
+
+	// Assert
+	assert.Assert(t).That(delta.GetCodes(), is.DeepEqualTo([]int{38, 2, 255, 128, 0, 48, 2, 0, 0, 64}), "Correct codes")
+}
+
+func Test_ExtendedColors_RGBParse(t *testing.T) {
+	// Arrange
+	target := ansi.Attributes{Foreground: randomRGBColour(), Background: randomRGBColour(), Bold: true}
+	raw := randomValues.String()
+	str := target.SetThis().ApplyTo(raw)
+	fmt.Println(str)
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(1), "One entry")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo(raw), "Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(target), "Correct attributes")
+}
+
+func Test_ExtendedColors_RGBParseSubParameters(t *testing.T) {
+	// Arrange
+	raw := randomValues.String()
+	str := "\u009b38:2::10:20:30;48:5:100m" + raw
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(1), "One entry")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo(raw), "Correct string")
+	assert.Assert(t).That(parsed[0].Attributes.Foreground, is.EqualTo(color.RGB(10, 20, 30)), "Correct foreground")
+	assert.Assert(t).That(parsed[0].Attributes.Background, is.EqualTo(color.Indexed(100)), "Correct background")
+}
+
+func randomRGBColour() color.Color {
+	return color.RGB(uint8(randomValues.IntBetween(0, 256)), uint8(randomValues.IntBetween(0, 256)), uint8(randomValues.IntBetween(0, 256)))
+}
+
+func Test_ExtendedColors_MalformedKeepsFollowingCodes(t *testing.T) {
+	// Arrange
+	bold := ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true}
+	testCases := map[string]string{
+		"Indexed out of range": "\u001b[38;5;300;1mX",
+		"RGB out of range":     "\u001b[48;2;10;300;30;1mX",
+		"Unknown mode":         "\u001b[38;7;1mX",
+	}
+	for name, str := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			parsed := ansi.ParseString(str)
+
+			// Assert
+			assert.Assert(t).That(parsed, is.DeepEqualTo([]ansi.AttributeString{{String: "X", Attributes: bold}}), "Bold applied, no colour")
+		})
+	}
+}
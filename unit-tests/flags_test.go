@@ -0,0 +1,126 @@
+package unit_tests
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+	"github.com/atrico-go/console/box_drawing"
+	"github.com/atrico-go/console/flags"
+)
+
+func Test_Flags_Parse(t *testing.T) {
+	// Arrange
+	var border box_drawing.BoxType
+	var mode flags.ColorMode
+	var highlight ansi.Attributes
+	var fore color.Color
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Var(flags.NewBoxTypeValue(box_drawing.BoxSingle, &border), "border", "Border")
+	flagSet.Var(flags.NewColorModeValue(flags.ColorAuto, &mode), "color", "Color mode")
+	flagSet.Var(flags.NewAttributesValue(ansi.NoAttributes, &highlight), "highlight", "Highlight")
+	flagSet.Var(flags.NewColorValue(color.None, &fore), "fore", "Foreground")
+
+	// Act
+	err := flagSet.Parse([]string{"--border=double", "--color=never", "--highlight=yellow on black", "--fore=#102030"})
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	assert.Assert(t).That(border, is.EqualTo(box_drawing.BoxDouble), "Correct border")
+	assert.Assert(t).That(mode, is.EqualTo(flags.ColorNever), "Correct mode")
+	assert.Assert(t).That(mode.Profile(os.Stdout), is.EqualTo(ansi.NoColor), "Correct profile")
+	assert.Assert(t).That(highlight, is.EqualTo(ansi.Attributes{Foreground: color.Yellow, Background: color.Black}), "Correct highlight")
+	assert.Assert(t).That(fore, is.EqualTo(color.RGB(0x10, 0x20, 0x30)), "Correct colour")
+}
+
+func Test_Flags_Defaults(t *testing.T) {
+	// Arrange
+	var border box_drawing.BoxType
+	var mode flags.ColorMode
+
+	// Act
+	borderValue := flags.NewBoxTypeValue(box_drawing.BoxHeavy, &border)
+	modeValue := flags.NewColorModeValue(flags.ColorAlways, &mode)
+
+	// Assert
+	assert.Assert(t).That(borderValue.String(), is.EqualTo("Heavy"), "Border string")
+	assert.Assert(t).That(borderValue.Type(), is.EqualTo("boxType"), "Border type")
+	assert.Assert(t).That(modeValue.String(), is.EqualTo("always"), "Mode string")
+	assert.Assert(t).That(modeValue.Type(), is.EqualTo("colorMode"), "Mode type")
+}
+
+func Test_Flags_Invalid(t *testing.T) {
+	// Arrange
+	var mode flags.ColorMode
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	flagSet.Var(flags.NewColorModeValue(flags.ColorAuto, &mode), "color", "Color mode")
+
+	// Act
+	err := flagSet.Parse([]string{"--color=sometimes"})
+
+	// Assert
+	assert.Assert(t).That(err.Error(), is.EqualTo(`invalid value "sometimes" for flag -color: invalid color mode: sometimes`), "Correct error")
+}
+
+func Test_Flags_Values(t *testing.T) {
+	// Arrange
+	var border box_drawing.BoxType
+	var mode flags.ColorMode
+	var highlight ansi.Attributes
+
+	// Act
+	borderValues := flags.NewBoxTypeValue(box_drawing.BoxNone, &border).Values()
+	modeValues := flags.NewColorModeValue(flags.ColorAuto, &mode).Values()
+	highlightValues := flags.NewAttributesValue(ansi.NoAttributes, &highlight).Values()
+
+	// Assert
+	assert.Assert(t).That(borderValues, is.DeepEqualTo([]string{"none", "single", "double", "heavy", "rounded",
+		"doubledash", "doubledashheavy", "tripledash", "tripledashheavy", "quadrupledash", "quadrupledashheavy"}), "Border values")
+	assert.Assert(t).That(modeValues, is.DeepEqualTo([]string{"auto", "always", "never"}), "Mode values")
+	assert.Assert(t).That(len(highlightValues), is.EqualTo(17+1+8), "Colours, on and styles")
+}
+
+func Test_Flags_ColorModeAutoNotTerminal(t *testing.T) {
+	// Arrange
+	defer restoreEnv("NO_COLOR", "COLORTERM", "TERM")()
+	setEnv("NO_COLOR", nil)
+	setEnv("COLORTERM", envValue("truecolor"))
+	file, _ := ioutil.TempFile("", "color_mode")
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	// Act
+	buffer := flags.ColorAuto.Profile(&bytes.Buffer{})
+	notTerminal := flags.ColorAuto.Profile(file)
+	always := flags.ColorAlways.Profile(&bytes.Buffer{})
+
+	// Assert
+	assert.Assert(t).That(buffer, is.EqualTo(ansi.NoColor), "Buffer")
+	assert.Assert(t).That(notTerminal, is.EqualTo(ansi.NoColor), "File")
+	assert.Assert(t).That(always, is.EqualTo(ansi.TrueColor), "Always")
+}
+
+func Test_Flags_ColorModeAutoTerminal(t *testing.T) {
+	// Arrange
+	defer restoreEnv("NO_COLOR", "COLORTERM", "TERM")()
+	setEnv("NO_COLOR", nil)
+	setEnv("COLORTERM", envValue("truecolor"))
+	original := flags.IsTerminal
+	defer func() { flags.IsTerminal = original }()
+	flags.IsTerminal = func(io.Writer) bool { return true }
+
+	// Act
+	profile := flags.ColorAuto.Profile(&bytes.Buffer{})
+
+	// Assert
+	assert.Assert(t).That(profile, is.EqualTo(ansi.TrueColor), "Terminal")
+}
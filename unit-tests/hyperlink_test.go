@@ -0,0 +1,58 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Hyperlink_Code(t *testing.T) {
+	// Arrange
+	url := "https://example.com/" + randomValues.String()
+
+	// Act
+	str := ansi.Hyperlink(url, "text")
+
+	// Assert
+	assert.Assert(t).That(str, is.EqualTo("\x1b]8;;"+url+"\x1b\\text\x1b]8;;\x1b\\"), "Correct codes")
+}
+
+func Test_Hyperlink_RoundTrip(t *testing.T) {
+	// Arrange
+	url := "https://example.com/" + randomValues.String()
+	attribs := ansi.Attributes{Foreground: randomColour(), Background: color.None, Underline: true}
+	raw1 := randomValues.String()
+	raw2 := randomValues.String()
+	str := ansi.Hyperlink(url, attribs.SetThis().ApplyTo(raw1)) + raw2
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo(raw1), "1: Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(attribs), "1: Correct attributes")
+	assert.Assert(t).That(parsed[0].Link, is.EqualTo(url), "1: Correct link")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo(raw2), "2: Correct string")
+	assert.Assert(t).That(parsed[1].Link, is.EqualTo(""), "2: No link")
+}
+
+func Test_Hyperlink_ParseTerminators(t *testing.T) {
+	// Arrange
+	str := "\x1b]8;id=1;file:///tmp\x07tmp\u009d8;;\u009c \x1b]0;title\x1b\\"
+
+	// Act
+	tokens := ansi.Tokenize(str)
+
+	// Assert
+	expected := []ansi.Token{
+		ansi.AttributeString{String: "tmp", Attributes: ansi.NoAttributes, Link: "file:///tmp"},
+		ansi.AttributeString{String: " ", Attributes: ansi.NoAttributes},
+		ansi.OperatingSystemCommand{Payload: "0;title"},
+	}
+	assert.Assert(t).That(tokens, is.DeepEqualTo(expected), "Correct tokens")
+}
@@ -0,0 +1,107 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+	"github.com/atrico-go/console/ansi/markup"
+)
+
+func Test_Markup_Render(t *testing.T) {
+	// Arrange
+	text := "[red on blue]error[/] [bold]done[/]"
+
+	// Act
+	str, err := markup.Render(text)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	expected := []ansi.AttributeString{
+		{String: "error", Attributes: ansi.Attributes{Foreground: color.Red, Background: color.Blue}},
+		{String: " ", Attributes: ansi.NoAttributes},
+		{String: "done", Attributes: ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true}},
+	}
+	assert.Assert(t).That(ansi.ParseString(str), is.DeepEqualTo(expected), "Correct parts")
+}
+
+func Test_Markup_Nesting(t *testing.T) {
+	// Arrange
+	text := "[yellow]a[underline italic]b[link=http://x]c[/][/underline italic]d[/]e"
+
+	// Act
+	parts, err := markup.Parse(text)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	yellow := ansi.Attributes{Foreground: color.Yellow, Background: color.None}
+	yellowStyled := ansi.Attributes{Foreground: color.Yellow, Background: color.None, Italic: true, Underline: true}
+	expected := []ansi.AttributeString{
+		{String: "a", Attributes: yellow},
+		{String: "b", Attributes: yellowStyled},
+		{String: "c", Attributes: yellowStyled, Link: "http://x"},
+		{String: "d", Attributes: yellow},
+		{String: "e", Attributes: ansi.NoAttributes},
+	}
+	assert.Assert(t).That(parts, is.DeepEqualTo(expected), "Correct parts")
+}
+
+func Test_Markup_ColourForms(t *testing.T) {
+	// Arrange
+	text := "[grey]a[/][#ff8000]b[/][indexed(208)]c[/][bright red on light grey]d[/]"
+
+	// Act
+	parts, err := markup.Parse(text)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	expected := []ansi.AttributeString{
+		{String: "a", Attributes: ansi.Attributes{Foreground: color.DarkGrey, Background: color.None}},
+		{String: "b", Attributes: ansi.Attributes{Foreground: color.RGB(255, 128, 0), Background: color.None}},
+		{String: "c", Attributes: ansi.Attributes{Foreground: color.Indexed(208), Background: color.None}},
+		{String: "d", Attributes: ansi.Attributes{Foreground: color.BrightRed, Background: color.LightGrey}},
+	}
+	assert.Assert(t).That(parts, is.DeepEqualTo(expected), "Same colours as ansi.ParseAttributes")
+}
+
+func Test_Markup_Escaping(t *testing.T) {
+	// Act
+	str, err := markup.Render("[[not a tag]] [green]ok[/]")
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	assert.Assert(t).That(ansi.StripString(str), is.EqualTo("[not a tag] ok"), "Literal brackets")
+}
+
+func Test_Markup_Errors(t *testing.T) {
+	for _, tc := range markupErrorTestCases {
+		t.Run(tc.markup, func(t *testing.T) {
+			// Act
+			_, err := markup.Render(tc.markup)
+
+			// Assert
+			assert.Assert(t).That(err, is.NotNil, "Error")
+			assert.Assert(t).That(err.Error(), is.EqualTo(tc.message), "Correct message")
+		})
+	}
+}
+
+type markupErrorTestCase struct {
+	markup  string
+	message string
+}
+
+var markupErrorTestCases = []markupErrorTestCase{
+	{"[purple]x[/]", "invalid tag [purple] at position 0: unknown colour or style 'purple'"},
+	{"ab[red on]x", "invalid tag [red on] at position 2: missing background colour after 'on'"},
+	{"[red on bold]x", "invalid tag [red on bold] at position 0: missing background colour after 'on'"},
+	{"[bright red purple]x", "invalid tag [bright red purple] at position 0: unknown colour or style 'purple'"},
+	{"[red green blue]x", "invalid tag [red green blue] at position 0: invalid attributes: red green blue (too many colors)"},
+	{"[red]x[/blue]", "close tag [/blue] at position 6 does not match open tag [red]"},
+	{"x[/]", "close tag [/] at position 1 has no open tag"},
+	{"[red", "unclosed tag at position 0"},
+	{"[]", "invalid tag [] at position 0: empty tag"},
+}
@@ -0,0 +1,90 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+	"github.com/atrico-go/console/box_drawing"
+)
+
+type styleConfig struct {
+	Color      color.Color         `json:"color"`
+	Attributes ansi.Attributes     `json:"attributes"`
+	Border     box_drawing.BoxType `json:"border"`
+}
+
+func Test_Marshal_JSONRoundTrip(t *testing.T) {
+	// Arrange
+	config := styleConfig{
+		Color:      color.RGB(1, 2, 3),
+		Attributes: ansi.Attributes{Foreground: color.White, Background: color.Indexed(52), Bold: true},
+		Border:     box_drawing.BoxDouble,
+	}
+
+	// Act
+	data, err := json.Marshal(config)
+	var parsed styleConfig
+	err2 := json.Unmarshal(data, &parsed)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No marshal error")
+	assert.Assert(t).That(string(data), is.EqualTo(`{"color":"#010203","attributes":"[White,Indexed(52),Bold]","border":"Double"}`), "Correct JSON")
+	assert.Assert(t).That(err2, is.Nil, "No unmarshal error")
+	assert.Assert(t).That(parsed, is.EqualTo(config), "Round trip")
+}
+
+func Test_Marshal_JSONTolerant(t *testing.T) {
+	// Arrange
+	data := `{"color":31,"attributes":"white on red underline","border":"boxHeavy"}`
+
+	// Act
+	var parsed styleConfig
+	err := json.Unmarshal([]byte(data), &parsed)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	assert.Assert(t).That(parsed.Color, is.EqualTo(color.Red), "Correct color")
+	assert.Assert(t).That(parsed.Attributes, is.EqualTo(ansi.Attributes{Foreground: color.White, Background: color.Red, Underline: true}), "Correct attributes")
+	assert.Assert(t).That(parsed.Border, is.EqualTo(box_drawing.BoxHeavy), "Correct border")
+}
+
+func Test_Marshal_Errors(t *testing.T) {
+	// Act
+	var parsed styleConfig
+	errColor := json.Unmarshal([]byte(`{"color":"purple"}`), &parsed)
+	errAttributes := json.Unmarshal([]byte(`{"attributes":"red shiny"}`), &parsed)
+	errBorder := json.Unmarshal([]byte(`{"border":"wavy"}`), &parsed)
+	_, errMarshalColor := color.Color(12).MarshalText()
+	_, errMarshalBorder := box_drawing.BoxType(99).MarshalText()
+
+	// Assert
+	assert.Assert(t).That(errColor.Error(), is.EqualTo("invalid color: purple"), "Color")
+	assert.Assert(t).That(errAttributes.Error(), is.EqualTo("invalid attributes: red shiny (unknown color or style: shiny)"), "Attributes")
+	assert.Assert(t).That(errBorder.Error(), is.EqualTo("invalid box type: wavy"), "Border")
+	assert.Assert(t).That(errMarshalColor.Error(), is.EqualTo("invalid color: 12"), "Marshal color")
+	assert.Assert(t).That(errMarshalBorder.Error(), is.EqualTo("invalid box type: 99"), "Marshal border")
+	assert.Assert(t).That(box_drawing.BoxType(99).String(), is.EqualTo("Unknown BoxType (99)"), "No panic")
+}
+
+func Test_Marshal_JSONNull(t *testing.T) {
+	// Arrange
+	data := `{"color":null,"attributes":null,"border":null}`
+	config := styleConfig{
+		Color:      color.Blue,
+		Attributes: ansi.Attributes{Foreground: color.Red, Background: color.None, Bold: true},
+		Border:     box_drawing.BoxRounded,
+	}
+
+	// Act
+	parsed := config
+	err := json.Unmarshal([]byte(data), &parsed)
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	assert.Assert(t).That(parsed, is.EqualTo(config), "Unchanged")
+}
@@ -0,0 +1,101 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Optimise_Redundant(t *testing.T) {
+	// Arrange
+	str := "\x1b[31m\x1b[31mred\x1b[0m\x1b[32m\x1b[1m\x1b[mplain\x1b[34m\x1b[0m"
+
+	// Act
+	optimised := ansi.Optimise(str)
+
+	// Assert
+	assert.Assert(t).That(optimised, is.EqualTo("\x1b[31mred\x1b[0mplain"), "Redundant codes removed")
+}
+
+func Test_Optimise_ChoosesReset(t *testing.T) {
+	// Arrange
+	attribs1 := ansi.Attributes{Foreground: color.Red, Background: color.Blue, Bold: true, Italic: true, Underline: true}
+	attribs2 := ansi.Attributes{Foreground: color.None, Background: color.None, Blink: true}
+	str := attribs1.SetThis().ApplyTo("a") + attribs1.CreateDeltaTo(attribs2).ApplyTo("b")
+
+	// Act
+	optimised := ansi.Optimise(str)
+
+	// Assert
+	assert.Assert(t).That(optimised, is.EqualTo("\x1b[31;44;1;3;4ma\x1b[0;5mb"), "Full reset is shorter")
+}
+
+func Test_Optimise_RoundTrip(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		// Arrange
+		str := randomAttributedString(5)
+
+		// Act
+		optimised := ansi.Optimise(str)
+
+		// Assert
+		assert.Assert(t).That(len(optimised) <= len(str), is.True, "%d: Not longer", i)
+		assert.Assert(t).That(mergeTokens(ansi.Tokenize(optimised)), is.DeepEqualTo(mergeTokens(ansi.Tokenize(str))), "%d: Equivalent", i)
+	}
+}
+
+func Test_Optimise_PreservesControlSequences(t *testing.T) {
+	// Arrange
+	str := "\x1b[31mab\x1b[2K\x1b[31mcd\x1b]0;title\x1b\\"
+
+	// Act
+	optimised := ansi.Optimise(str)
+
+	// Assert
+	assert.Assert(t).That(optimised, is.EqualTo("\x1b[31mab\x1b[2Kcd\x1b]0;title\x1b\\"), "Control sequences preserved")
+}
+
+// Random text with redundant codes between segments
+func randomAttributedString(segments int) string {
+	str := ""
+	current := ansi.NoAttributes
+	for i := 0; i < segments; i++ {
+		attribs := randomAttributes()
+		if randomValues.Bool() {
+			str += ansi.ResetAll.GetCodeString() + attribs.SetThis().GetCodeString()
+		} else {
+			str += current.CreateDeltaTo(attribs).GetCodeString()
+		}
+		str += attribs.SetThis().GetCodeString() + randomValues.String()
+		current = attribs
+	}
+	return str
+}
+
+func randomAttributes() ansi.Attributes {
+	attribs := ansi.NoAttributes
+	if randomValues.Bool() {
+		attribs.Foreground = randomColour()
+	}
+	if randomValues.Bool() {
+		attribs.Background = randomColour()
+	}
+	attribs.Bold = randomValues.Bool()
+	attribs.Underline = randomValues.Bool()
+	return attribs
+}
+
+func Test_Optimise_AttributesBeforeControlSequence(t *testing.T) {
+	// Arrange
+	str := "\x1b[41m\x1b[K\x1b[0m"
+
+	// Act
+	optimised := ansi.Optimise(str)
+
+	// Assert
+	assert.Assert(t).That(optimised, is.EqualTo("\x1b[41m\x1b[K\x1b[0m"), "Background applies to erase")
+}
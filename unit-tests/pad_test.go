@@ -0,0 +1,68 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Pad_Alignment(t *testing.T) {
+	for _, tc := range padTestCases {
+		t.Run(fmt.Sprintf("%v", tc.align), func(t *testing.T) {
+			// Arrange
+			attribs := ansi.Attributes{Foreground: randomColour(), Background: color.None}
+			str := attribs.SetThis().ApplyTo("中ab")
+
+			// Act
+			padded := ansi.Pad(str, 9, tc.align, ansi.NoAttributes)
+
+			// Assert
+			assert.Assert(t).That(ansi.StringWidth(padded), is.EqualTo(9), "Correct width")
+			assert.Assert(t).That(ansi.StripString(padded), is.EqualTo(tc.expected), "Correct text")
+		})
+	}
+}
+
+func Test_Pad_PaddingAttributes(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: color.White, Background: color.Blue}
+	padding := ansi.Attributes{Foreground: color.None, Background: color.Blue}
+
+	// Act
+	padded := ansi.Pad(attribs.SetThis().ApplyTo("row"), 6, ansi.AlignLeft, padding)
+	parsed := ansi.ParseString(padded)
+
+	// Assert
+	expected := []ansi.AttributeString{
+		{String: "row", Attributes: attribs},
+		{String: "   ", Attributes: padding},
+	}
+	assert.Assert(t).That(parsed, is.DeepEqualTo(expected), "Correct parts")
+}
+
+func Test_Pad_TooWide(t *testing.T) {
+	// Arrange
+	str := randomValues.String()
+
+	// Act
+	padded := ansi.Pad(str, 3, ansi.AlignRight, ansi.NoAttributes)
+
+	// Assert
+	assert.Assert(t).That(padded, is.EqualTo(str), "Unchanged")
+}
+
+type padTestCase struct {
+	align    ansi.Alignment
+	expected string
+}
+
+var padTestCases = []padTestCase{
+	{ansi.AlignLeft, "中ab     "},
+	{ansi.AlignRight, "     中ab"},
+	{ansi.AlignCentre, "  中ab   "},
+}
@@ -0,0 +1,123 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_ParseColor(t *testing.T) {
+	for _, tc := range parseColorTestCases {
+		t.Run(tc.str, func(t *testing.T) {
+			// Act
+			col, err := color.ParseColor(tc.str)
+
+			// Assert
+			assert.Assert(t).That(err, is.Nil, "No error")
+			assert.Assert(t).That(col, is.EqualTo(tc.expected), "Correct color")
+		})
+	}
+}
+
+func Test_ParseColor_String(t *testing.T) {
+	for _, col := range []color.Color{color.Black, color.BrightMagenta, color.None, color.Indexed(123), color.RGB(1, 22, 255)} {
+		t.Run(col.String(), func(t *testing.T) {
+			// Act
+			parsed, err := color.ParseColor(col.String())
+
+			// Assert
+			assert.Assert(t).That(err, is.Nil, "No error")
+			assert.Assert(t).That(parsed, is.EqualTo(col), "Correct color")
+		})
+	}
+}
+
+func Test_ParseColor_Invalid(t *testing.T) {
+	for _, str := range []string{"purple", "29", "indexed(256)", "#12345", "rgb(1,2)", "", "-31", "r-e-d"} {
+		t.Run(str, func(t *testing.T) {
+			// Act
+			_, err := color.ParseColor(str)
+
+			// Assert
+			assert.Assert(t).That(err, is.NotNil, "Error")
+			assert.Assert(t).That(err.Error(), is.EqualTo("invalid color: "+str), "Correct message")
+		})
+	}
+}
+
+func Test_ParseAttributes(t *testing.T) {
+	for _, tc := range parseAttributesTestCases {
+		t.Run(tc.str, func(t *testing.T) {
+			// Act
+			attribs, err := ansi.ParseAttributes(tc.str)
+
+			// Assert
+			assert.Assert(t).That(err, is.Nil, "No error")
+			assert.Assert(t).That(attribs, is.EqualTo(tc.expected), "Correct attributes")
+		})
+	}
+}
+
+func Test_ParseAttributes_String(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: color.RGB(10, 20, 30), Background: randomColour(), Dim: true, Reverse: true}
+
+	// Act
+	parsed, err := ansi.ParseAttributes(attribs.String())
+
+	// Assert
+	assert.Assert(t).That(err, is.Nil, "No error")
+	assert.Assert(t).That(parsed, is.EqualTo(attribs), "Correct attributes")
+}
+
+func Test_ParseAttributes_Invalid(t *testing.T) {
+	// Act
+	_, err1 := ansi.ParseAttributes("white on")
+	_, err2 := ansi.ParseAttributes("white shiny")
+	_, err3 := ansi.ParseAttributes("red green blue")
+
+	// Assert
+	assert.Assert(t).That(err1.Error(), is.EqualTo("invalid attributes: white on (missing color after 'on')"), "Missing background")
+	assert.Assert(t).That(err2.Error(), is.EqualTo("invalid attributes: white shiny (unknown color or style: shiny)"), "Unknown word")
+	assert.Assert(t).That(err3.Error(), is.EqualTo("invalid attributes: red green blue (too many colors)"), "Too many colors")
+}
+
+type parseColorTestCase struct {
+	str      string
+	expected color.Color
+}
+
+var parseColorTestCases = []parseColorTestCase{
+	{"red", color.Red},
+	{"BrightCyan", color.BrightCyan},
+	{"bright-cyan", color.BrightCyan},
+	{"Light Gray", color.LightGrey},
+	{"grey", color.DarkGrey},
+	{"gray", color.DarkGrey},
+	{"none", color.None},
+	{"31", color.Red},
+	{"104", color.BrightBlue},
+	{"Indexed(208)", color.Indexed(208)},
+	{"#FF8000", color.RGB(255, 128, 0)},
+	{"rgb(1, 2, 3)", color.RGB(1, 2, 3)},
+}
+
+type parseAttributesTestCase struct {
+	str      string
+	expected ansi.Attributes
+}
+
+var parseAttributesTestCases = []parseAttributesTestCase{
+	{"", ansi.NoAttributes},
+	{"white on red bold", ansi.Attributes{Foreground: color.White, Background: color.Red, Bold: true}},
+	{"on blue", ansi.Attributes{Foreground: color.None, Background: color.Blue}},
+	{"Underline rgb(1, 2, 3)", ansi.Attributes{Foreground: color.RGB(1, 2, 3), Background: color.None, Underline: true}},
+	{"[Yellow,Black,Italic]", ansi.Attributes{Foreground: color.Yellow, Background: color.Black, Italic: true}},
+	{"bright red on black", ansi.Attributes{Foreground: color.BrightRed, Background: color.Black}},
+	{"light grey bold", ansi.Attributes{Foreground: color.LightGrey, Background: color.None, Bold: true}},
+	{"white on Dark Gray", ansi.Attributes{Foreground: color.White, Background: color.DarkGrey}},
+}
@@ -0,0 +1,133 @@
+package unit_tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Profile_TrueColor(t *testing.T) {
+	// Arrange
+	defer ansi.SetColorProfile(ansi.GetColorProfile())
+	ansi.SetColorProfile(ansi.TrueColor)
+	target := ansi.Attributes{Foreground: color.RGB(255, 135, 0), Background: color.None}
+
+	// Act
+	parsed := ansi.ParseString(target.SetThis().ApplyTo("text"))
+
+	// Assert
+	assert.Assert(t).That(parsed[0].Attributes.Foreground, is.EqualTo(color.RGB(255, 135, 0)), "No change")
+}
+
+func Test_Profile_Ansi256(t *testing.T) {
+	// Arrange
+	defer ansi.SetColorProfile(ansi.GetColorProfile())
+	ansi.SetColorProfile(ansi.Ansi256)
+	target := ansi.Attributes{Foreground: color.RGB(255, 135, 0), Background: color.RGB(18, 18, 18)}
+
+	// Act
+	parsed := ansi.ParseString(target.SetThis().ApplyTo("text"))
+
+	// Assert
+	assert.Assert(t).That(parsed[0].Attributes.Foreground, is.EqualTo(color.Indexed(208)), "Nearest cube colour")
+	assert.Assert(t).That(parsed[0].Attributes.Background, is.EqualTo(color.Indexed(233)), "Nearest grey")
+}
+
+func Test_Profile_Ansi16(t *testing.T) {
+	// Arrange
+	defer ansi.SetColorProfile(ansi.GetColorProfile())
+	ansi.SetColorProfile(ansi.Ansi16)
+	target := ansi.Attributes{Foreground: color.RGB(250, 10, 10), Background: color.Indexed(12)}
+
+	// Act
+	parsed := ansi.ParseString(target.SetThis().ApplyTo("text"))
+
+	// Assert
+	assert.Assert(t).That(parsed[0].Attributes.Foreground, is.EqualTo(color.BrightRed), "Nearest standard colour")
+	assert.Assert(t).That(parsed[0].Attributes.Background, is.EqualTo(color.BrightBlue), "Standard palette entry")
+}
+
+func Test_Profile_NoColor(t *testing.T) {
+	// Arrange
+	defer ansi.SetColorProfile(ansi.GetColorProfile())
+	ansi.SetColorProfile(ansi.NoColor)
+	target := ansi.Attributes{Foreground: color.Red, Background: color.RGB(1, 2, 3), Bold: true}
+
+	// Act
+	parsed := ansi.ParseString(target.SetThis().ApplyTo("text"))
+	reset := target.ResetThis().GetCodeString()
+
+	// Assert
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true}), "Only style")
+	assert.Assert(t).That(ansi.NoAttributes.CreateDeltaTo(ansi.Attributes{Foreground: color.Red, Background: color.None}).GetCodeString(), is.EqualTo(""), "No code")
+	assert.Assert(t).That(reset, is.EqualTo(ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true}.ResetThis().GetCodeString()), "Only style reset")
+}
+
+func Test_Profile_Detect(t *testing.T) {
+	for _, tc := range profileTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			defer restoreEnv("NO_COLOR", "COLORTERM", "TERM")()
+			setEnv("NO_COLOR", tc.noColor)
+			setEnv("COLORTERM", tc.colorTerm)
+			setEnv("TERM", tc.term)
+
+			// Act
+			profile := ansi.DetectColorProfile()
+
+			// Assert
+			assert.Assert(t).That(profile, is.EqualTo(tc.expected), "Correct profile")
+		})
+	}
+}
+
+type profileTestCase struct {
+	name      string
+	noColor   *string
+	colorTerm *string
+	term      *string
+	expected  ansi.ColorProfile
+}
+
+var profileTestCases = []profileTestCase{
+	{"NO_COLOR", envValue("1"), envValue("truecolor"), envValue("xterm-256color"), ansi.NoColor},
+	{"NO_COLOR empty", envValue(""), envValue("truecolor"), envValue("xterm-256color"), ansi.TrueColor},
+	{"COLORTERM", nil, envValue("truecolor"), envValue("xterm"), ansi.TrueColor},
+	{"256color", nil, nil, envValue("xterm-256color"), ansi.Ansi256},
+	{"xterm", nil, nil, envValue("xterm"), ansi.Ansi16},
+	{"dumb", nil, nil, envValue("dumb"), ansi.NoColor},
+	{"unset", nil, nil, nil, ansi.NoColor},
+}
+
+func envValue(value string) *string {
+	return &value
+}
+
+func setEnv(key string, value *string) {
+	if value != nil {
+		os.Setenv(key, *value)
+	} else {
+		os.Unsetenv(key)
+	}
+}
+
+func restoreEnv(keys ...string) func() {
+	original := make(map[string]*string, len(keys))
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			original[key] = &value
+		} else {
+			original[key] = nil
+		}
+	}
+	return func() {
+		for key, value := range original {
+			setEnv(key, value)
+		}
+	}
+}
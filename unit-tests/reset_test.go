@@ -0,0 +1,83 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Reset_ResetAll(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: randomColour(), Background: randomColour(), Bold: true}
+	raw1 := randomValues.String()
+	raw2 := randomValues.String()
+	str := attribs.SetThis().ApplyTo(raw1) + ansi.ResetAll.ApplyTo(raw2)
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(attribs), "1: Correct attributes")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo(raw2), "2: Correct string")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(ansi.NoAttributes), "2: No attributes")
+}
+
+func Test_Reset_NoParameters(t *testing.T) {
+	// Arrange
+	str := "\u009b1;31mbold\u009bmplain"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo("plain"), "2: Correct string")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(ansi.NoAttributes), "2: No attributes")
+}
+
+func Test_Reset_Combined(t *testing.T) {
+	// Arrange
+	str := "\u009b1;41mfirst\u009b0;32msecond\u009b;4;45mthird\u009b49mfourth"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(4), "4 entries")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.None, Background: color.Red, Bold: true}), "1: Correct attributes")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.Green, Background: color.None}), "2: Correct attributes")
+	assert.Assert(t).That(parsed[2].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.None, Background: color.Magenta, Underline: true}), "3: Correct attributes")
+	assert.Assert(t).That(parsed[3].String, is.EqualTo("fourth"), "4: Correct string")
+	assert.Assert(t).That(parsed[3].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.None, Background: color.None, Underline: true}), "4: Background reset")
+}
+
+func Test_Reset_DefaultBackground(t *testing.T) {
+	// Arrange
+	str := "\u009b33;44myellow on blue\u009b49myellow"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(ansi.Attributes{Foreground: color.Yellow, Background: color.None}), "2: Background reset")
+}
+
+func Test_Reset_UnderlineStyle(t *testing.T) {
+	// Arrange
+	str := "\x1b[1;4:3ma\x1b[4:0mb"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(parsed, is.DeepEqualTo([]ansi.AttributeString{
+		{String: "a", Attributes: ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true, Underline: true}},
+		{String: "b", Attributes: ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true}},
+	}), "Underline style 0 is off")
+}
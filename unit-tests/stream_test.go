@@ -0,0 +1,89 @@
+package unit_tests
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Stream_SplitWrites(t *testing.T) {
+	// Arrange
+	attribs1 := ansi.Attributes{Foreground: color.RGB(10, 200, 30), Background: color.None, Bold: true}
+	attribs2 := ansi.Attributes{Foreground: color.None, Background: color.Indexed(99)}
+	str := attribs1.SetThis().ApplyTo("héllo 世界") + "\x1b[2K" + attribs1.CreateDeltaTo(attribs2).ApplyTo("wörld")
+	tokens := make([]ansi.Token, 0)
+	parser := ansi.NewStreamParser(func(token ansi.Token) { tokens = append(tokens, token) })
+
+	// Act
+	data := []byte(str)
+	for i := range data {
+		parser.Write(data[i : i+1])
+	}
+	parser.Close()
+
+	// Assert
+	assert.Assert(t).That(mergeTokens(tokens), is.DeepEqualTo(ansi.Tokenize(str)), "Same as whole string")
+	assert.Assert(t).That(parser.Attributes(), is.EqualTo(attribs2), "Final attributes")
+}
+
+func Test_Stream_IncompleteSequence(t *testing.T) {
+	// Arrange
+	tokens := make([]ansi.Token, 0)
+	parser := ansi.NewStreamParser(func(token ansi.Token) { tokens = append(tokens, token) })
+
+	// Act
+	parser.Write([]byte("abc\x1b[3"))
+	beforeClose := len(tokens)
+	parser.Write([]byte("1mdef\x1b["))
+	parser.Close()
+
+	// Assert
+	assert.Assert(t).That(beforeClose, is.EqualTo(1), "Text emitted")
+	expected := []ansi.Token{
+		ansi.AttributeString{String: "abc", Attributes: ansi.NoAttributes},
+		ansi.AttributeString{String: "def", Attributes: ansi.Attributes{Foreground: color.Red, Background: color.None}},
+	}
+	assert.Assert(t).That(tokens, is.DeepEqualTo(expected), "Incomplete sequence discarded")
+}
+
+func Test_Stream_TokenReader(t *testing.T) {
+	// Arrange
+	str := "\x1b[31mred\x1b[0m\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\\x1b[H"
+	reader := ansi.NewTokenReader(strings.NewReader(str))
+	tokens := make([]ansi.Token, 0)
+
+	// Act
+	var err error
+	for err == nil {
+		var token ansi.Token
+		if token, err = reader.Next(); err == nil {
+			tokens = append(tokens, token)
+		}
+	}
+
+	// Assert
+	assert.Assert(t).That(err, is.EqualTo(io.EOF), "EOF")
+	assert.Assert(t).That(tokens, is.DeepEqualTo(ansi.Tokenize(str)), "Same as whole string")
+}
+
+// Merge consecutive text with the same attributes
+func mergeTokens(tokens []ansi.Token) []ansi.Token {
+	merged := make([]ansi.Token, 0, len(tokens))
+	for _, token := range tokens {
+		if text, ok := token.(ansi.AttributeString); ok && len(merged) > 0 {
+			if last, ok := merged[len(merged)-1].(ansi.AttributeString); ok && last.Attributes == text.Attributes && last.Link == text.Link {
+				last.String += text.String
+				merged[len(merged)-1] = last
+				continue
+			}
+		}
+		merged = append(merged, token)
+	}
+	return merged
+}
@@ -0,0 +1,65 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Styles_SetCodes(t *testing.T) {
+	// Arrange
+	target := ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true, Underline: true}
+
+	// Act
+	delta := target.SetThis()
+
+	// Assert
+	assert.Assert(t).That(delta.GetCodes(), is.DeepEqualTo([]int{1, 4}), "Bold and underline")
+}
+
+func Test_Styles_ResetCodes(t *testing.T) {
+	// Arrange
+	original := ansi.Attributes{Foreground: color.None, Background: color.None, Italic: true, Strikethrough: true}
+
+	// Act
+	delta := original.ResetThis()
+
+	// Assert
+	assert.Assert(t).That(delta.GetCodes(), is.DeepEqualTo([]int{23, 29}), "Italic and strikethrough reset")
+}
+
+func Test_Styles_SharedReset(t *testing.T) {
+	// Arrange
+	original := ansi.Attributes{Foreground: color.None, Background: color.None, Bold: true, Dim: true}
+	target := ansi.Attributes{Foreground: color.None, Background: color.None, Dim: true}
+
+	// Act
+	delta := original.CreateDeltaTo(target)
+	newAttributes := original.Modify(delta)
+	fmt.Printf("%s => %s = %s\n", original, target, newAttributes)
+
+	// Assert
+	assert.Assert(t).That(delta.GetCodes(), is.DeepEqualTo([]int{22, 2}), "Reset both, set dim")
+	assert.Assert(t).That(newAttributes, is.EqualTo(target), "Correct attributes")
+}
+
+func Test_Styles_RoundTrip(t *testing.T) {
+	// Arrange
+	fore := randomColour()
+	target := ansi.Attributes{Foreground: fore, Background: color.None, Bold: true, Blink: true, Reverse: true, Hidden: true}
+	raw := randomValues.String()
+	str := target.SetThis().ApplyTo(raw)
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(1), "One entry")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo(raw), "Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(target), "Correct attributes")
+}
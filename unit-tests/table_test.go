@@ -0,0 +1,232 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+	"github.com/atrico-go/console/box_drawing"
+	"github.com/atrico-go/console/table"
+)
+
+func Test_Table_Simple(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.SetHeader("Name", "Qty")
+	tbl.AddRow("apple", "3")
+	tbl.AddRow("fig", "12")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"┌───────┬─────┐",
+		"│ Name  │ Qty │",
+		"├───────┼─────┤",
+		"│ apple │ 3   │",
+		"│ fig   │ 12  │",
+		"└───────┴─────┘",
+	}
+	assert.Assert(t).That(lines, is.DeepEqualTo(expected), "Correct lines")
+}
+
+func Test_Table_HeavyHeaderAndSeparators(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.HeavyHeader = true
+	tbl.RowSeparators = true
+	tbl.Columns = []table.Column{{}, {Align: ansi.AlignRight}}
+	tbl.SetHeader("Name", "Qty")
+	tbl.AddRow("apple", "3")
+	tbl.AddRow("fig", "12")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"┌───────┬─────┐",
+		"│ Name  │ Qty │",
+		"┝━━━━━━━┿━━━━━┥",
+		"│ apple │   3 │",
+		"├───────┼─────┤",
+		"│ fig   │  12 │",
+		"└───────┴─────┘",
+	}
+	assert.Assert(t).That(lines, is.DeepEqualTo(expected), "Correct lines")
+}
+
+func Test_Table_Widths(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxDouble)
+	tbl.Columns = []table.Column{
+		{MinWidth: 4},
+		{MaxWidth: 5},
+		{MaxWidth: 6, Wrap: true, Align: ansi.AlignCentre},
+	}
+	red := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	tbl.AddRow("a", red.SetThis().ApplyTo("truncated"), "wrapped text")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"╔══════╦═══════╦════════╗",
+		"║ a    ║ trun… ║ wrappe ║",
+		"║      ║       ║ d text ║",
+		"╚══════╩═══════╩════════╝",
+	}
+	stripped := make([]string, len(lines))
+	for i, line := range lines {
+		stripped[i] = ansi.StripString(line)
+	}
+	assert.Assert(t).That(stripped, is.DeepEqualTo(expected), "Correct lines")
+	assert.Assert(t).That(ansi.ParseString(lines[1])[1], is.EqualTo(ansi.AttributeString{String: "trun…", Attributes: red}), "Truncated with attributes")
+}
+
+func Test_Table_Span(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.RowSeparators = true
+	tbl.AddRow("a", "b", "c")
+	tbl.Rows = append(tbl.Rows, table.Row{Cells: []table.Cell{{Text: "spanning", Span: 2}, {Text: "d"}}})
+	tbl.Rows = append(tbl.Rows, table.Row{Cells: []table.Cell{{Text: "e"}, {Text: "f", Span: 2}}})
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"┌─────┬────┬───┐",
+		"│ a   │ b  │ c │",
+		"├─────┴────┼───┤",
+		"│ spanning │ d │",
+		"├─────┬────┴───┤",
+		"│ e   │ f      │",
+		"└─────┴────────┘",
+	}
+	assert.Assert(t).That(lines, is.DeepEqualTo(expected), "Correct lines")
+}
+
+func Test_Table_Attributes(t *testing.T) {
+	// Arrange
+	column := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	row := ansi.Attributes{Foreground: color.None, Background: color.Blue}
+	cell := ansi.Attributes{Foreground: color.Green, Background: color.None, Bold: true}
+	tbl := table.NewTable(box_drawing.BoxNone)
+	tbl.Padding = 0
+	tbl.Columns = []table.Column{{}, {Attributes: &column}}
+	tbl.AddRow("a", "b")
+	tbl.Rows = append(tbl.Rows, table.Row{Cells: []table.Cell{{Text: "c"}, {Text: "d", Attributes: &cell}}, Attributes: &row})
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	assert.Assert(t).That(len(lines), is.EqualTo(2), "No border lines")
+	assert.Assert(t).That(ansi.ParseString(lines[0]), is.DeepEqualTo([]ansi.AttributeString{
+		{String: "a", Attributes: ansi.NoAttributes},
+		{String: "b", Attributes: column},
+	}), "Column attributes")
+	assert.Assert(t).That(ansi.ParseString(lines[1]), is.DeepEqualTo([]ansi.AttributeString{
+		{String: "c", Attributes: ansi.Attributes{Foreground: color.None, Background: color.Blue}},
+		{String: "d", Attributes: ansi.Attributes{Foreground: color.Green, Background: color.Blue, Bold: true}},
+	}), "Row and cell attributes layered")
+}
+
+func Test_Table_NoBorder(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxNone)
+	tbl.SetHeader("Name", "Qty")
+	tbl.AddRow("apple", "3")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{" Name   Qty ", " apple  3   "}), "Correct lines")
+}
+
+func Test_Table_CharacterSet(t *testing.T) {
+	// Arrange
+	defer box_drawing.SetCharacterSet(box_drawing.GetCharacterSet())
+	box_drawing.SetCharacterSet(box_drawing.AsciiSet)
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.SetHeader("x")
+	tbl.AddRow("y")
+
+	// Act
+	text := tbl.String()
+
+	// Assert
+	assert.Assert(t).That(text, is.EqualTo("+---+\n| x |\n+---+\n| y |\n+---+"), "Ascii")
+}
+
+func Test_Table_Empty(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+
+	// Act
+	text := tbl.String()
+
+	// Assert
+	assert.Assert(t).That(text, is.EqualTo(""), "Nothing rendered")
+}
+
+func Test_Table_SpanWithoutSeparators(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.AddRow("a", "b")
+	tbl.Rows = append(tbl.Rows, table.Row{Cells: []table.Cell{{Text: "spanned", Span: 2}}})
+	tbl.AddRow("c", "d")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"┌────┬────┐",
+		"│ a  │ b  │",
+		"│ spanned │",
+		"│ c  │ d  │",
+		"└────┴────┘",
+	}
+	assert.Assert(t).That(lines, is.DeepEqualTo(expected), "Lines stop at the edge of the spanned row")
+}
+
+func Test_Table_WideCharacterInNarrowColumn(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.Columns = []table.Column{{MaxWidth: 1, Wrap: true}, {MaxWidth: 1}}
+	tbl.AddRow("日本", "中")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	expected := []string{
+		"┌───┬───┐",
+		"│ … │ … │",
+		"│ … │   │",
+		"└───┴───┘",
+	}
+	assert.Assert(t).That(lines, is.DeepEqualTo(expected), "Wide characters truncated")
+}
+
+func Test_Table_NegativePadding(t *testing.T) {
+	// Arrange
+	tbl := table.NewTable(box_drawing.BoxSingle)
+	tbl.Padding = -1
+	tbl.AddRow("a", "b")
+
+	// Act
+	lines := tbl.Lines()
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"┌─┬─┐", "│a│b│", "└─┴─┘"}), "No padding")
+}
@@ -0,0 +1,72 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Tokenize_ControlSequences(t *testing.T) {
+	// Arrange
+	str := "\x1b[?25l\x1b[31mred\x1b[2;5Hmore\x1b[K\x1b[0m"
+
+	// Act
+	tokens := ansi.Tokenize(str)
+
+	// Assert
+	red := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	expected := []ansi.Token{
+		ansi.ControlSequence{Parameters: "?25", Final: 'l'},
+		ansi.AttributeString{String: "red", Attributes: red},
+		ansi.ControlSequence{Parameters: "2;5", Final: 'H'},
+		ansi.AttributeString{String: "more", Attributes: red},
+		ansi.ControlSequence{Final: 'K'},
+	}
+	assert.Assert(t).That(tokens, is.DeepEqualTo(expected), "Correct tokens")
+}
+
+func Test_Tokenize_EscapeSequences(t *testing.T) {
+	// Arrange
+	str := "\x1b(0lqk\x1b(B\x1b7"
+
+	// Act
+	tokens := ansi.Tokenize(str)
+
+	// Assert
+	expected := []ansi.Token{
+		ansi.EscapeSequence{Intermediate: "(", Final: '0'},
+		ansi.AttributeString{String: "lqk", Attributes: ansi.NoAttributes},
+		ansi.EscapeSequence{Intermediate: "(", Final: 'B'},
+		ansi.EscapeSequence{Final: '7'},
+	}
+	assert.Assert(t).That(tokens, is.DeepEqualTo(expected), "Correct tokens")
+}
+
+func Test_Tokenize_ControlSequenceString(t *testing.T) {
+	// Arrange
+	seq := ansi.ControlSequence{Parameters: "?25", Final: 'h'}
+
+	// Act
+	str := seq.String()
+
+	// Assert
+	assert.Assert(t).That(str, is.EqualTo("\x1b[?25h"), "Correct string")
+}
+
+func Test_Tokenize_ParseStringIgnoresControlSequences(t *testing.T) {
+	// Arrange
+	str := "\x1b[32mab\x1b[1Acd\x1b[2J\x1b[0mef\x1b[3"
+
+	// Act
+	parsed := ansi.ParseString(str)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo("abcd"), "1: Correct string")
+	assert.Assert(t).That(parsed[0].Attributes.Foreground, is.EqualTo(color.Green), "1: Correct foreground")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo("ef"), "2: Incomplete sequence discarded")
+}
@@ -0,0 +1,70 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Truncate_NotRequired(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: randomColour(), Background: color.None}
+	str := attribs.SetThis().ApplyTo("short")
+
+	// Act
+	truncated := ansi.Truncate(str, 10, ansi.Ellipsis)
+	parsed := ansi.ParseString(truncated)
+
+	// Assert
+	assert.Assert(t).That(len(parsed), is.EqualTo(1), "One entry")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo("short"), "Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(attribs), "Correct attributes")
+	assert.Assert(t).That(truncated, is.EqualTo(str+ansi.ResetAll.GetCodeString()), "Ends with reset")
+}
+
+func Test_Truncate_Attributes(t *testing.T) {
+	// Arrange
+	attribs1 := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	attribs2 := ansi.Attributes{Foreground: color.Green, Background: color.Blue, Bold: true}
+	str := attribs1.SetThis().ApplyTo("abc") + attribs1.CreateDeltaTo(attribs2).ApplyTo("defgh")
+
+	// Act
+	truncated := ansi.Truncate(str, 6, ansi.Ellipsis)
+	parsed := ansi.ParseString(truncated)
+
+	// Assert
+	assert.Assert(t).That(ansi.StringWidth(truncated), is.EqualTo(6), "Correct width")
+	assert.Assert(t).That(len(parsed), is.EqualTo(2), "2 entries")
+	assert.Assert(t).That(parsed[0].String, is.EqualTo("abc"), "1: Correct string")
+	assert.Assert(t).That(parsed[0].Attributes, is.EqualTo(attribs1), "1: Correct attributes")
+	assert.Assert(t).That(parsed[1].String, is.EqualTo("de…"), "2: Correct string")
+	assert.Assert(t).That(parsed[1].Attributes, is.EqualTo(attribs2), "2: Correct attributes")
+	assert.Assert(t).That(truncated[len(truncated)-4:], is.EqualTo(ansi.ResetAll.GetCodeString()), "Ends with reset")
+}
+
+func Test_Truncate_WideCharacters(t *testing.T) {
+	// Arrange
+	str := "日本語テキスト"
+
+	// Act
+	truncated := ansi.Truncate(str, 6, ansi.Ellipsis)
+
+	// Assert
+	assert.Assert(t).That(truncated, is.EqualTo("日本…"), "Wide character not split")
+	assert.Assert(t).That(ansi.StringWidth(truncated), is.EqualTo(5), "Correct width")
+}
+
+func Test_Truncate_NoRoomForTail(t *testing.T) {
+	// Arrange
+	str := randomValues.String()
+
+	// Act
+	truncated := ansi.Truncate(str, 2, "...")
+
+	// Assert
+	assert.Assert(t).That(truncated, is.EqualTo(".."), "Truncated tail")
+}
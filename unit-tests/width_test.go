@@ -0,0 +1,85 @@
+package unit_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Width_StripString(t *testing.T) {
+	// Arrange
+	raw1 := randomValues.String()
+	raw2 := randomValues.String()
+	attribs := ansi.Attributes{Foreground: randomColour(), Background: randomColour(), Italic: true}
+	str := attribs.SetThis().ApplyTo(raw1) + "\x1b[1A" + ansi.Hyperlink("http://x", raw2) + ansi.ResetAll.GetCodeString()
+
+	// Act
+	stripped := ansi.StripString(str)
+
+	// Assert
+	assert.Assert(t).That(stripped, is.EqualTo(raw1+raw2), "Correct string")
+}
+
+func Test_Width_StringWidth(t *testing.T) {
+	for _, tc := range widthTestCases {
+		t.Run(tc.text, func(t *testing.T) {
+			// Arrange
+			str := ansi.Attributes{Foreground: color.Red, Background: color.None}.SetThis().ApplyTo(tc.text)
+
+			// Act
+			width := ansi.StringWidth(str)
+
+			// Assert
+			assert.Assert(t).That(width, is.EqualTo(tc.width), "Correct width")
+		})
+	}
+}
+
+func Test_Width_RuneWidth(t *testing.T) {
+	for _, tc := range runeWidthTestCases {
+		t.Run(fmt.Sprintf("%U", tc.rune), func(t *testing.T) {
+			// Act
+			width := ansi.RuneWidth(tc.rune)
+
+			// Assert
+			assert.Assert(t).That(width, is.EqualTo(tc.width), "Correct width")
+		})
+	}
+}
+
+type widthTestCase struct {
+	text  string
+	width int
+}
+
+var widthTestCases = []widthTestCase{
+	{"hello", 5},
+	{"日本語", 6},
+	{"e\u0301te\u0301", 3},
+	{"ok \U0001F44D", 5},
+	{"\U0001F44D\U0001F3FD", 2},
+	{"\U0001F468\u200d\U0001F469\u200d\U0001F467", 2},
+	{"\u2764\ufe0f", 2},
+	{"ｆｕｌｌ", 8},
+}
+
+type runeWidthTestCase struct {
+	rune
+	width int
+}
+
+var runeWidthTestCases = []runeWidthTestCase{
+	{'a', 1},
+	{'\t', 0},
+	{'\u0301', 0},
+	{'\u200b', 0},
+	{'中', 2},
+	{'한', 2},
+	{'\U0001F600', 2},
+	{'─', 1},
+}
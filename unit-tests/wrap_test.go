@@ -0,0 +1,91 @@
+package unit_tests
+
+import (
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Wrap_Plain(t *testing.T) {
+	// Arrange
+	str := "the quick brown fox jumps over the lazy dog"
+
+	// Act
+	lines := ansi.Wrap(str, 10, ansi.WrapOptions{})
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"the quick", "brown fox", "jumps over", "the lazy", "dog"}), "Correct lines")
+}
+
+func Test_Wrap_Attributes(t *testing.T) {
+	// Arrange
+	attribs := ansi.Attributes{Foreground: color.Red, Background: color.None, Bold: true}
+	str := "plain " + attribs.SetThis().ApplyTo("red text here") + attribs.ResetThis().ApplyTo(" end")
+
+	// Act
+	lines := ansi.Wrap(str, 10, ansi.WrapOptions{})
+
+	// Assert
+	assert.Assert(t).That(len(lines), is.EqualTo(3), "3 lines")
+	expected := [][]ansi.AttributeString{
+		{{String: "plain ", Attributes: ansi.NoAttributes}, {String: "red", Attributes: attribs}},
+		{{String: "text here", Attributes: attribs}},
+		{{String: "end", Attributes: ansi.NoAttributes}},
+	}
+	for i, line := range lines {
+		assert.Assert(t).That(ansi.ParseString(line), is.DeepEqualTo(expected[i]), "%d: Correct parts", i)
+	}
+	assert.Assert(t).That(lines[1], is.EqualTo(attribs.SetThis().ApplyTo("text here")+ansi.ResetAll.GetCodeString()), "Attributes reapplied and reset")
+}
+
+func Test_Wrap_LongWord(t *testing.T) {
+	// Arrange
+	str := "a verylongword b"
+
+	// Act
+	soft := ansi.Wrap(str, 5, ansi.WrapOptions{})
+	hard := ansi.Wrap(str, 5, ansi.WrapOptions{HardBreak: true})
+
+	// Assert
+	assert.Assert(t).That(soft, is.DeepEqualTo([]string{"a", "verylongword", "b"}), "Word overflows")
+	assert.Assert(t).That(hard, is.DeepEqualTo([]string{"a", "veryl", "ongwo", "rd b"}), "Word broken")
+}
+
+func Test_Wrap_HangingIndent(t *testing.T) {
+	// Arrange
+	str := "one two three four\nfive six seven"
+
+	// Act
+	lines := ansi.Wrap(str, 9, ansi.WrapOptions{HangingIndent: 2})
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"one two", "  three", "  four", "five six", "  seven"}), "Correct lines")
+}
+
+func Test_Wrap_CharacterWiderThanLine(t *testing.T) {
+	// Act
+	lines := ansi.Wrap("日本 x", 1, ansi.WrapOptions{HardBreak: true})
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"日", "本", "x"}), "No empty line after wide character")
+}
+
+func Test_Wrap_IndentWiderThanLine(t *testing.T) {
+	// Act
+	lines := ansi.Wrap("ab", 1, ansi.WrapOptions{HardBreak: true, HangingIndent: 2})
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"a", "  b"}), "Correct lines")
+}
+
+func Test_Wrap_IndentWiderThanLineWithSpaces(t *testing.T) {
+	// Act
+	lines := ansi.Wrap("aa bb cc", 3, ansi.WrapOptions{HardBreak: true, HangingIndent: 5})
+
+	// Assert
+	assert.Assert(t).That(lines, is.DeepEqualTo([]string{"aa", "     b", "     b", "     c", "     c"}), "Correct lines")
+}
@@ -0,0 +1,85 @@
+package unit_tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/atrico-go/testing/assert"
+	"github.com/atrico-go/testing/is"
+
+	"github.com/atrico-go/console/ansi"
+	"github.com/atrico-go/console/ansi/color"
+)
+
+func Test_Writer_OnlyDeltaWritten(t *testing.T) {
+	// Arrange
+	output := strings.Builder{}
+	writer := ansi.NewWriter(&output)
+	attribs1 := ansi.Attributes{Foreground: color.Red, Background: color.None}
+	attribs2 := ansi.Attributes{Foreground: color.Red, Background: color.None, Bold: true}
+
+	// Act
+	writer.SetAttributes(attribs1)
+	writer.WriteString("a")
+	writer.SetAttributes(ansi.NoAttributes)
+	writer.SetAttributes(attribs2)
+	writer.WriteString("b")
+	writer.WriteString("c")
+	writer.Close()
+
+	// Assert
+	assert.Assert(t).That(output.String(), is.EqualTo("\x1b[31ma\x1b[1mbc\x1b[39;22m"), "Only changes written")
+}
+
+func Test_Writer_PushPop(t *testing.T) {
+	// Arrange
+	output := strings.Builder{}
+	writer := ansi.NewWriter(&output)
+	outer := ansi.Attributes{Foreground: color.None, Background: color.Blue}
+	inner := ansi.Attributes{Foreground: color.Yellow, Background: color.Blue, Underline: true}
+
+	// Act
+	writer.Push(outer)
+	writer.WriteString("out")
+	writer.Push(inner)
+	writer.WriteString("in")
+	writer.Pop()
+	writer.WriteString("out")
+	writer.Pop()
+	writer.Pop()
+	writer.WriteString("plain")
+
+	// Assert
+	expected := []ansi.AttributeString{
+		{String: "out", Attributes: outer},
+		{String: "in", Attributes: inner},
+		{String: "out", Attributes: outer},
+		{String: "plain", Attributes: ansi.NoAttributes},
+	}
+	assert.Assert(t).That(ansi.ParseString(output.String()), is.DeepEqualTo(expected), "Correct parts")
+	assert.Assert(t).That(writer.Attributes(), is.EqualTo(ansi.NoAttributes), "No attributes")
+}
+
+func Test_Writer_FlushRestores(t *testing.T) {
+	// Arrange
+	output := strings.Builder{}
+	writer := ansi.NewWriter(&output)
+	attribs := ansi.Attributes{Foreground: randomColour(), Background: randomColour(), Italic: true}
+
+	// Act
+	func() {
+		defer writer.Flush()
+		writer.SetAttributes(attribs)
+		writer.WriteString("text")
+	}()
+	afterFlush := ansi.NoAttributes
+	for _, part := range ansi.Tokenize(output.String() + "end") {
+		afterFlush = part.(ansi.AttributeString).Attributes
+	}
+	writer.WriteString("more")
+
+	// Assert
+	assert.Assert(t).That(afterFlush, is.EqualTo(ansi.NoAttributes), "Restored")
+	assert.Assert(t).That(writer.Attributes(), is.EqualTo(attribs), "Attributes retained")
+	assert.Assert(t).That(strings.HasSuffix(output.String(), attribs.SetThis().ApplyTo("more")), is.True, "Reapplied")
+}